@@ -0,0 +1,356 @@
+// euisubset 扫描源码中用到的 FontAwesome 图标, 生成只包含这些图标的字体和图标数据, 用来减小程序体积.
+//
+// 内置的三个字体大约有 700 KB, 只用几个图标时可以在 main 包中加上:
+//
+//	//go:generate go run github.com/twgh/xc-elementui/cmd/euisubset
+//
+// 执行 go generate 后会在 main 包目录中生成 euiicons_subset.go 和 euiicons 目录, 然后使用 eui_subset 构建标签编译:
+//
+//	go build -tags eui_subset
+//
+// 会扫描这些写法, 图标名, 码点必须是字面量:
+//   - ButtonOption, EditOption, EditSlot, ElementOption 的 Icon, IconHex, IconUnicode, IconStyle 字段
+//   - SetIconName, SetIconHex, SetIconUnicode 函数
+//
+// 图标名是变量或拼接出来的时候扫描不到, 会输出警告, 这些图标可以用 -icons 参数添加, 如 -icons "fa-sun,fa-moon".
+// 运行时没有的图标会显示为空白, 添加或修改图标后需重新执行 go generate.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/twgh/xc-elementui/eui"
+	"github.com/twgh/xc-elementui/internal/fontsubset"
+)
+
+// fontFiles 是风格对应的字体文件名, 与 eui/res 中的相同.
+var fontFiles = map[string]string{
+	"fa-solid":   "fa-solid-900.ttf",
+	"fa-regular": "fa-regular-400.ttf",
+	"fa-brands":  "fa-brands-400.ttf",
+}
+
+// minIcon 是 icons.min.json 中的图标信息, 与 eui/internal/genicons 生成的相同.
+type minIcon struct {
+	Aliases    []string `json:"a,omitempty"`
+	Categories []string `json:"c,omitempty"`
+	Label      string   `json:"l,omitempty"`
+	Styles     []string `json:"s"`
+	Terms      []string `json:"t,omitempty"`
+	Unicode    int32    `json:"u"`
+}
+
+// iconRef 是源码中引用的一个图标.
+type iconRef struct {
+	name    string // 图标名, 如'fa-solid fa-paw'
+	unicode int32  // 码点, 使用 IconHex, IconUnicode 时不为 0
+	style   string // 使用 IconHex, IconUnicode 时优先使用的风格
+	pos     string // 源码位置
+}
+
+func main() {
+	dir := flag.String("dir", ".", "要扫描的源码目录, 包括子目录, 生成的 go 文件也放在这里")
+	out := flag.String("out", "euiicons", "存放字体和图标数据的目录, 相对于 -dir")
+	extra := flag.String("icons", "", "额外要包含的图标名, 用逗号分开, 如'fa-sun,fa-regular fa-moon'")
+	flag.Parse()
+	if err := run(*dir, *out, *extra); err != nil {
+		fmt.Fprintln(os.Stderr, "euisubset:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, out, extra string) error {
+	if eui.Icons.Len() == 0 {
+		return errors.New("no built-in icons, do not run euisubset with the eui_subset build tag")
+	}
+	refs, pkg, err := scanDir(dir, filepath.Join(dir, out))
+	if err != nil {
+		return err
+	}
+	for _, name := range strings.Split(extra, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			refs = append(refs, iconRef{name: name, pos: "-icons"})
+		}
+	}
+	// 元素自带功能用到的图标, 如编辑框的清空图标
+	for _, name := range eui.BuiltinIcons {
+		refs = append(refs, iconRef{name: name, pos: "eui"})
+	}
+	if pkg == "" {
+		return errors.New("no go package in " + dir)
+	}
+
+	// 确定每个图标要使用的风格, 与 SetIconName, SetIconUnicode 的规则相同
+	used := make(map[string]map[string]bool) // 带前缀的图标名对应用到的风格
+	runes := make(map[string][]rune)         // 风格对应的字符
+	for _, ref := range refs {
+		var icon eui.Icon
+		var style string
+		if ref.unicode > 0 {
+			style = eui.Icons.StyleOf(ref.unicode, ref.style)
+			icon, _ = eui.Icons.ByCodepoint(ref.unicode)
+		} else {
+			icon, style, _ = eui.Icons.Resolve(ref.name)
+		}
+		if _, ok := fontFiles[style]; !ok || icon.Name == "" {
+			fmt.Fprintf(os.Stderr, "euisubset: %s: unknown icon %q\n", ref.pos, ref.displayName())
+			continue
+		}
+		key := icon.Prefix + icon.Name
+		if used[key] == nil {
+			used[key] = make(map[string]bool)
+		}
+		if !used[key][style] {
+			used[key][style] = true
+			runes[style] = append(runes[style], icon.Unicode)
+		}
+	}
+
+	// 生成字体和图标数据
+	outDir := filepath.Join(dir, out)
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return err
+	}
+	icons := make(map[string]minIcon)
+	eui.Icons.Range(func(icon eui.Icon) bool {
+		styles := used[icon.Prefix+icon.Name]
+		if len(styles) == 0 || icon.Prefix != "fa-" {
+			return true
+		}
+		m := minIcon{Aliases: icon.Aliases, Categories: icon.Categories, Label: icon.Label, Terms: icon.Terms, Unicode: icon.Unicode}
+		for style := range styles {
+			m.Styles = append(m.Styles, strings.TrimPrefix(style, "fa-"))
+		}
+		sort.Strings(m.Styles)
+		icons[icon.Name] = m
+		return true
+	})
+	data, err := json.Marshal(icons)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(outDir, "icons.min.json"), data, 0644); err != nil {
+		return err
+	}
+	var styles []string
+	for style, file := range fontFiles {
+		path := filepath.Join(outDir, file)
+		if len(runes[style]) == 0 {
+			// 删除之前生成的, 没用到的字体
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			continue
+		}
+		ttf, err := fontsubset.Subset(eui.IconFontData(style), runes[style])
+		if err != nil {
+			return fmt.Errorf("%s: %v", style, err)
+		}
+		if err := os.WriteFile(path, ttf, 0644); err != nil {
+			return err
+		}
+		styles = append(styles, style)
+	}
+	sort.Strings(styles)
+
+	src, err := format.Source(generate(pkg, filepath.ToSlash(out), styles))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "euiicons_subset.go"), src, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "euisubset: %d icons, styles %v\n", len(icons), styles)
+	return nil
+}
+
+// scanDir 扫描目录及子目录中的 go 文件, 返回引用的图标和 dir 目录中的包名. 跳过 skip 目录, testdata, vendor 和以'.'开头的目录.
+func scanDir(dir, skip string) (refs []iconRef, pkg string, err error) {
+	fset := token.NewFileSet()
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != dir && (path == skip || name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") || d.Name() == "euiicons_subset.go" {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		if filepath.Dir(path) == filepath.Clean(dir) && pkg == "" {
+			pkg = f.Name.Name
+		}
+		refs = append(refs, scanFile(fset, f)...)
+		return nil
+	})
+	return refs, pkg, err
+}
+
+// scanFile 找出文件中引用的图标, 不是字面量的图标名会输出警告.
+func scanFile(fset *token.FileSet, f *ast.File) []iconRef {
+	var refs []iconRef
+	warn := func(n ast.Node, what string) {
+		fmt.Fprintf(os.Stderr, "euisubset: %s: %s is not a literal, add it with -icons\n", fset.Position(n.Pos()), what)
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			if !isOptionType(n.Type) {
+				return true
+			}
+			var ref iconRef
+			for _, elt := range n.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				switch key.Name {
+				case "Icon", "IconHex", "IconUnicode", "IconStyle":
+					v, ok := literal(kv.Value)
+					if !ok {
+						warn(kv.Value, key.Name)
+						continue
+					}
+					ref.set(key.Name, v)
+				}
+			}
+			if ref.name != "" || ref.unicode > 0 {
+				ref.pos = fset.Position(n.Pos()).String()
+				refs = append(refs, ref)
+			}
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok || len(n.Args) == 0 {
+				return true
+			}
+			field := map[string]string{"SetIconName": "Icon", "SetIconHex": "IconHex", "SetIconUnicode": "IconUnicode"}[sel.Sel.Name]
+			if field == "" {
+				return true
+			}
+			v, ok := literal(n.Args[0])
+			if !ok {
+				warn(n.Args[0], sel.Sel.Name)
+				return true
+			}
+			ref := iconRef{pos: fset.Position(n.Pos()).String()}
+			ref.set(field, v)
+			if len(n.Args) > 1 {
+				if style, ok := literal(n.Args[1]); ok {
+					ref.style = style
+				}
+			}
+			refs = append(refs, ref)
+		}
+		return true
+	})
+	return refs
+}
+
+// set 根据选项字段名设置图标引用.
+func (r *iconRef) set(field, value string) {
+	switch field {
+	case "Icon":
+		r.name = value
+	case "IconHex":
+		// IconUnicode 优先于 IconHex
+		if r.unicode == 0 {
+			u, _ := strconv.ParseInt(value, 16, 32)
+			r.unicode = int32(u)
+		}
+	case "IconUnicode":
+		u, _ := strconv.ParseInt(value, 0, 32)
+		r.unicode = int32(u)
+	case "IconStyle":
+		r.style = value
+	}
+}
+
+// displayName 返回用于提示的图标名.
+func (r iconRef) displayName() string {
+	if r.unicode > 0 {
+		return strconv.FormatInt(int64(r.unicode), 16)
+	}
+	return r.name
+}
+
+// isOptionType 判断类型是否为 ButtonOption, EditOption, ElementOption, 可以带包名.
+func isOptionType(t ast.Expr) bool {
+	if sel, ok := t.(*ast.SelectorExpr); ok {
+		t = sel.Sel
+	}
+	id, ok := t.(*ast.Ident)
+	return ok && (id.Name == "ButtonOption" || id.Name == "EditOption" || id.Name == "EditSlot" || id.Name == "ElementOption")
+}
+
+// literal 返回字符串或整数字面量的值.
+func literal(e ast.Expr) (string, bool) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok {
+		return "", false
+	}
+	switch lit.Kind {
+	case token.STRING:
+		s, err := strconv.Unquote(lit.Value)
+		return s, err == nil
+	case token.INT:
+		return lit.Value, true
+	}
+	return "", false
+}
+
+// generate 生成加载裁剪后的字体和图标数据的 go 代码.
+//
+// pkg: 包名.
+//
+// out: 存放字体和图标数据的目录, 相对于 go 文件.
+//
+// styles: 用到的风格.
+func generate(pkg, out string, styles []string) []byte {
+	var b strings.Builder
+	b.WriteString("// Code generated by euisubset; DO NOT EDIT.\n\n")
+	b.WriteString("//go:build eui_subset\n\n")
+	b.WriteString("package " + pkg + "\n\n")
+	b.WriteString("import (\n\t_ \"embed\"\n\n\t\"github.com/twgh/xc-elementui/eui\"\n)\n\n")
+	b.WriteString("var (\n")
+	for _, style := range styles {
+		fmt.Fprintf(&b, "\t//go:embed %s/%s\n\t%s []byte\n", out, fontFiles[style], varName(style))
+	}
+	fmt.Fprintf(&b, "\t//go:embed %s/icons.min.json\n\teuiIconsJson []byte\n)\n\n", out)
+	b.WriteString("func init() {\n\tfonts := map[string][]byte{\n")
+	for _, style := range styles {
+		fmt.Fprintf(&b, "\t\t%q: %s,\n", style, varName(style))
+	}
+	b.WriteString("\t}\n\tif err := eui.LoadIconSubset(fonts, euiIconsJson); err != nil {\n\t\tpanic(err)\n\t}\n}\n")
+	return []byte(b.String())
+}
+
+// varName 返回风格对应的字体变量名, 如'fa-solid'是 euiIconsSolid.
+func varName(style string) string {
+	s := strings.TrimPrefix(style, "fa-")
+	return "euiIcons" + strings.ToUpper(s[:1]) + s[1:]
+}
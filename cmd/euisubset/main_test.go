@@ -0,0 +1,45 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func Test_scanFile(t *testing.T) {
+	src := `package main
+
+func f(e *eui.Elementui, name string) {
+	e.CreateButton("a", 0, eui.ButtonOption{Icon: "fa-solid fa-paw"})
+	e.CreateEdit(0, eui.EditOption{IconHex: "f1b0", IconStyle: "fa-regular"})
+	e.CreateEdit(0, eui.EditOption{Append: eui.EditSlot{Icon: "fa-magnifying-glass"}})
+	e.CreateElement(0, ElementOption{IconUnicode: 61872, IconHex: "f015"})
+	btn.SetIconName("fa-house")
+	btn.SetIconHex("f1b0", "fa-regular")
+	btn.SetIconName(name)
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "a.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refs := scanFile(fset, f)
+	want := []iconRef{
+		{name: "fa-solid fa-paw"},
+		{unicode: 0xf1b0, style: "fa-regular"},
+		{name: "fa-magnifying-glass"},
+		{unicode: 61872},
+		{name: "fa-house"},
+		{unicode: 0xf1b0, style: "fa-regular"},
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("got %d refs, want %d: %+v", len(refs), len(want), refs)
+	}
+	for i, ref := range refs {
+		ref.pos = ""
+		if ref != want[i] {
+			t.Errorf("ref %d = %+v, want %+v", i, ref, want[i])
+		}
+	}
+}
@@ -0,0 +1,357 @@
+//go:build windows
+
+package eui
+
+import (
+	"time"
+
+	"github.com/twgh/xcgui/widget"
+	"github.com/twgh/xcgui/xc"
+	"github.com/twgh/xcgui/xcc"
+)
+
+// 输入建议用到的虚拟键码.
+
+const (
+	vk_Return = 0x0D // 回车键
+	vk_Escape = 0x1B // Esc 键
+	vk_Up     = 0x26 // 上方向键
+	vk_Down   = 0x28 // 下方向键
+)
+
+// Autocomplete 是带输入建议的编辑框, 继承 Edit. 输入时根据内容获取建议, 显示在编辑框下方的弹出框中, 可用鼠标或上下键和回车选择.
+//   - 弹出框是编辑框所在窗口的子元素, 不参与窗口布局, 不会被父元素挡住.
+type Autocomplete struct {
+	Edit
+}
+
+// CreateAutocomplete 创建带输入建议的编辑框.
+//   - 停止输入 Debounce 后调用 Fetch 获取建议, 之前未返回的结果会被丢弃.
+//   - 内部除了编辑框注册的事件外, 还注册了编辑框内容改变事件, 按键事件, 获得/失去焦点事件.
+//
+// hParent: 父元素或父窗口句柄.
+//
+// opts: AutocompleteOption 输入建议选项, 可不填.
+func (e *Elementui) CreateAutocomplete(hParent int, opts ...AutocompleteOption) *Autocomplete {
+	var opt AutocompleteOption
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Debounce <= 0 {
+		opt.Debounce = autocompleteDebounce * time.Millisecond
+	}
+	if opt.MaxRows < 1 {
+		opt.MaxRows = autocompleteMaxRows
+	}
+
+	edit := updateEdit(e, false, hParent, 0, opt.EditOption)
+	a := &Autocomplete{Edit: *edit}
+	s := &autocompleteState{
+		hEdit:          a.H,
+		hover:          -1,
+		itemHeight:     autocompleteItemHeight * e.dpi / 96,
+		padding:        autocompletePadding * e.dpi / 96,
+		scrollBar:      scrollBarSize * e.dpi / 96,
+		maxRows:        opt.MaxRows,
+		fetch:          opt.Fetch,
+		debounce:       opt.Debounce,
+		triggerOnFocus: opt.IsTriggerOnFocus,
+		itemPainter:    opt.ItemPainter,
+	}
+	s.eui = e
+	s.painter = "onDrawAutocomplete"
+	s.round = e.theme.BorderRadiusBase * e.dpi / 96
+	if es := a.edState(); es != nil {
+		es.suggest = s
+	}
+
+	// 注册编辑框内容改变事件, 用于获取建议
+	a.Event_EDIT_CHANGED1(onAutocompleteChanged)
+	// 注册按键事件, 用于在弹出框中选择建议
+	a.Event_KEYDOWN1(onAutocompleteKeyDown)
+	// 注册获得焦点事件, 用于获得焦点时获取建议
+	a.Event_SETFOCUS1(onAutocompleteSetFocus)
+	// 注册失去焦点事件, 用于隐藏弹出框
+	a.Event_KILLFOCUS1(onAutocompleteKillFocus)
+	// 注册元素销毁事件, 用于销毁弹出框. 编辑框的状态可能已被删除, 所以直接使用 s
+	a.Event_DESTROY1(func(hEle int, pbHandled *bool) int {
+		s.cancel()
+		if s.hPopup > 0 {
+			xc.XEle_Destroy(s.hPopup)
+		}
+		return 0
+	})
+	return a
+}
+
+// SetFetch 设置获取建议的函数.
+//   - 在 UI 线程中调用, 可以在 goroutine 中获取建议, 然后调用 cb, cb 会把结果送回 UI 线程显示, 可在任何线程中调用.
+//   - cb 的参数为空时隐藏弹出框.
+//
+// f: 获取建议的函数, query 是编辑框的内容.
+func (a *Autocomplete) SetFetch(f func(query string, cb func([]Suggestion))) *Autocomplete {
+	if s := a.acState(); s != nil {
+		s.fetch = f
+	}
+	return a
+}
+
+// SetItemPainter 设置建议的绘制函数, 可以绘制图标, 多行文字等.
+//
+// painter: 绘制函数, 为 nil 时使用默认的, 默认的绘制函数会高亮匹配输入内容的部分.
+func (a *Autocomplete) SetItemPainter(painter SuggestionPainter) *Autocomplete {
+	if s := a.acState(); s != nil {
+		s.itemPainter = painter
+	}
+	return a
+}
+
+// SetOnSelect 设置选中建议后调用的函数. 调用时建议的 Value 已填入编辑框.
+//
+// f: 选中建议后调用的函数.
+func (a *Autocomplete) SetOnSelect(f func(item Suggestion)) *Autocomplete {
+	if s := a.acState(); s != nil {
+		s.onSelect = f
+	}
+	return a
+}
+
+// GetSuggestions 获取弹出框中当前显示的建议.
+func (a *Autocomplete) GetSuggestions() []Suggestion {
+	if s := a.acState(); s != nil {
+		return s.items
+	}
+	return nil
+}
+
+// acState 获取输入建议的状态. 编辑框不是用 CreateAutocomplete 创建的或已经销毁时返回 nil.
+func (a *Autocomplete) acState() *autocompleteState {
+	return getAutocompleteState(a.H)
+}
+
+// AutocompleteOption 输入建议选项.
+type AutocompleteOption struct {
+	// 编辑框选项, 如 DefaultText, Icon, Clearable, Append.
+	EditOption
+
+	// 获取建议的函数, 也可以用 SetFetch 设置.
+	//  - 在 UI 线程中调用, 可以在 goroutine 中获取建议, 然后调用 cb, cb 会把结果送回 UI 线程显示, 可在任何线程中调用.
+	Fetch func(query string, cb func([]Suggestion))
+	// 停止输入多久后获取建议, 默认 300 毫秒.
+	Debounce time.Duration
+	// 获得焦点时是否获取建议, 此时编辑框可能是空的.
+	IsTriggerOnFocus bool
+	// 弹出框最多显示的行数, 默认 8, 再多就滚动.
+	MaxRows int32
+	// 建议的绘制函数, 为 nil 时使用默认的.
+	ItemPainter SuggestionPainter
+}
+
+// schedule 在 delay 后获取建议, 取消之前还未获取或未返回的.
+//
+// delay: 延迟时间, 为 0 时立即获取.
+func (s *autocompleteState) schedule(delay time.Duration) {
+	s.cancel()
+	seq, hEdit := s.seq, s.hEdit
+	if delay <= 0 {
+		fetchSuggestions(hEdit, seq)
+		return
+	}
+	s.timer = time.AfterFunc(delay, func() {
+		xc.XC_CallUT(func() {
+			fetchSuggestions(hEdit, seq)
+		})
+	})
+}
+
+// cancel 停止防抖计时器, 之前获取的结果返回时会被丢弃.
+func (s *autocompleteState) cancel() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.seq++
+}
+
+// fetchSuggestions 用编辑框当前的内容获取建议, 结果在 UI 线程中显示. 在 UI 线程中调用.
+//
+// seq: 安排获取时的序号, 之后又安排过获取时不再获取.
+func fetchSuggestions(hEdit int, seq int) {
+	s := getAutocompleteState(hEdit)
+	if s == nil || s.seq != seq || s.fetch == nil {
+		return
+	}
+	query := xc.XEdit_GetText_Temp(hEdit)
+	s.fetch(query, func(items []Suggestion) {
+		xc.XC_CallUT(func() {
+			// 编辑框已销毁, 失去焦点或内容又变了
+			s := getAutocompleteState(hEdit)
+			if s == nil || s.seq != seq || !xc.XEle_IsFocus(hEdit) {
+				return
+			}
+			s.setItems(query, items)
+			s.showPopup()
+		})
+	})
+}
+
+// showPopup 在编辑框下方显示弹出框, 宽度与编辑框相同. 没有建议时隐藏.
+func (s *autocompleteState) showPopup() {
+	if len(s.items) == 0 {
+		s.hidePopup()
+		return
+	}
+	if s.hPopup == 0 {
+		s.createPopup()
+	}
+	rc := xc.RECT{Right: xc.XEle_GetWidth(s.hEdit), Bottom: xc.XEle_GetHeight(s.hEdit)}
+	xc.XEle_RectClientToWndClient(s.hEdit, &rc)
+	rc.Top = rc.Bottom + autocompleteGap*s.eui.dpi/96
+	rc.Bottom = rc.Top + s.popupHeight()
+	xc.XEle_SetRect(s.hPopup, &rc, false, xcc.AdjustLayout_All, 0)
+	xc.XEle_Show(s.hPopup, true)
+	s.show = true
+	// 弹出框的大小可能变小了, 重绘整个窗口
+	xc.XWnd_Redraw(xc.XEle_GetHWINDOW(s.hPopup), false)
+}
+
+// hidePopup 隐藏弹出框.
+func (s *autocompleteState) hidePopup() {
+	if s.hPopup > 0 && s.show {
+		xc.XEle_Show(s.hPopup, false)
+		xc.XWnd_Redraw(xc.XEle_GetHWINDOW(s.hPopup), false)
+	}
+	s.show = false
+	s.hover = -1
+}
+
+// createPopup 在编辑框所在窗口中创建弹出框.
+func (s *autocompleteState) createPopup() {
+	s.hPopup = xc.XEle_Create(0, 0, 0, 0, xc.XEle_GetHWINDOW(s.hEdit))
+	setState(s.hPopup, s)
+	// 不参与窗口布局, 也不获取焦点, 点击建议时编辑框不会失去焦点
+	xc.XWidget_LayoutItem_EnableFloat(s.hPopup, true)
+	xc.XEle_EnableFocus(s.hPopup, false)
+	xc.XEle_EnableBkTransparent(s.hPopup, true)
+
+	popup := widget.NewElementByHandle(s.hPopup)
+	// 注册元素绘制事件
+	popup.Event_PAINT1(onDrawEle)
+	// 注册鼠标移动事件, 用于显示鼠标停留的建议
+	popup.Event_MOUSEMOVE1(onAutocompleteMouseMove)
+	// 注册鼠标左键弹起事件, 用于选中建议
+	popup.Event_LBUTTONUP1(onAutocompleteLButtonUp)
+	// 注册鼠标滚轮事件
+	popup.Event_MOUSEWHEEL1(onAutocompleteMouseWheel)
+	// 注册元素销毁事件
+	popup.Event_DESTROY1(onDestroyEle)
+}
+
+// selectItem 把建议填入编辑框, 隐藏弹出框, 然后调用 onSelect.
+//
+// i: 建议的下标.
+func (s *autocompleteState) selectItem(i int) {
+	if i < 0 || i >= len(s.items) {
+		return
+	}
+	item := s.items[i]
+	s.cancel()
+	s.selecting = true
+	xc.XEdit_SetText(s.hEdit, item.Value)
+	xc.XEdit_MoveEnd(s.hEdit)
+	s.selecting = false
+	s.hidePopup()
+	xc.XEle_Redraw(s.hEdit, false)
+	if s.onSelect != nil {
+		s.onSelect(item)
+	}
+}
+
+// 输入建议编辑框内容改变事件, 防抖后获取建议
+func onAutocompleteChanged(hEle int, pbHandled *bool) int {
+	if s := getAutocompleteState(hEle); s != nil && !s.selecting {
+		s.schedule(s.debounce)
+	}
+	return 0
+}
+
+// 输入建议编辑框获得焦点事件
+func onAutocompleteSetFocus(hEle int, pbHandled *bool) int {
+	if s := getAutocompleteState(hEle); s != nil && s.triggerOnFocus {
+		s.schedule(0)
+	}
+	return 0
+}
+
+// 输入建议编辑框失去焦点事件, 隐藏弹出框并丢弃还未返回的结果
+func onAutocompleteKillFocus(hEle int, pbHandled *bool) int {
+	if s := getAutocompleteState(hEle); s != nil {
+		s.cancel()
+		s.hidePopup()
+	}
+	return 0
+}
+
+// 输入建议编辑框按键事件. 弹出框显示时, 上下键选择建议, 回车键填入选中的建议, Esc 键隐藏弹出框.
+func onAutocompleteKeyDown(hEle int, wParam, lParam uintptr, pbHandled *bool) int {
+	s := getAutocompleteState(hEle)
+	if s == nil || !s.show {
+		return 0
+	}
+	switch wParam {
+	case vk_Up, vk_Down:
+		delta := 1
+		if wParam == vk_Up {
+			delta = -1
+		}
+		if s.moveHover(delta, xc.XEle_GetHeight(s.hPopup)) {
+			xc.XEle_Redraw(s.hPopup, false)
+		}
+	case vk_Return:
+		if s.hover < 0 {
+			return 0
+		}
+		s.selectItem(s.hover)
+	case vk_Escape:
+		s.cancel()
+		s.hidePopup()
+	default:
+		return 0
+	}
+	*pbHandled = true
+	return 0
+}
+
+// 输入建议弹出框鼠标移动事件
+func onAutocompleteMouseMove(hEle int, nFlags uint32, pPt *xc.POINT, pbHandled *bool) int {
+	if s := getAutocompleteState(hEle); s != nil {
+		if i := s.hitTest(pPt.Y); i != s.hover && i != -1 {
+			s.hover = i
+			xc.XEle_Redraw(hEle, false)
+		}
+	}
+	return 0
+}
+
+// 输入建议弹出框鼠标左键弹起事件, 选中建议
+func onAutocompleteLButtonUp(hEle int, nFlags uint32, pPt *xc.POINT, pbHandled *bool) int {
+	if s := getAutocompleteState(hEle); s != nil {
+		s.selectItem(s.hitTest(pPt.Y))
+	}
+	return 0
+}
+
+// 输入建议弹出框鼠标滚轮事件, 每滚动一格移动一行.
+//   - nFlags 与 WM_MOUSEWHEEL 的 wParam 相同, 高 16 位是滚动距离, 向上滚动为正.
+func onAutocompleteMouseWheel(hEle int, nFlags uint32, pPt *xc.POINT, pbHandled *bool) int {
+	s := getAutocompleteState(hEle)
+	if s == nil {
+		return 0
+	}
+	delta := int32(int16(nFlags >> 16))
+	if s.scrollTo(s.scrollY-delta*s.itemHeight/120, xc.XEle_GetHeight(hEle)) {
+		xc.XEle_Redraw(hEle, false)
+	}
+	*pbHandled = true
+	return 0
+}
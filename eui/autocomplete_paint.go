@@ -0,0 +1,249 @@
+package eui
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// 输入建议弹出框默认的行高, 间距和最多显示的行数, 未按 dpi 缩放.
+const (
+	autocompleteItemHeight = 34
+	autocompletePadding    = 6
+	autocompleteMaxRows    = 8
+	autocompleteGap        = 4   // 弹出框和编辑框的间距
+	autocompleteDebounce   = 300 // 停止输入多少毫秒后获取建议
+)
+
+// Suggestion 是一条输入建议.
+type Suggestion struct {
+	// 显示的文本, 选中后会填入编辑框.
+	Value string
+	// 附带的数据, 如 id, 选中后可在 SetOnSelect 设置的函数中取回.
+	Data interface{}
+}
+
+// SuggestionPaintContext 绘制一条输入建议时需要的信息.
+type SuggestionPaintContext struct {
+	Canvas Canvas     // 画布
+	Theme  *Theme     // 编辑框所用的主题
+	Rect   Rect       // 这条建议的位置
+	Item   Suggestion // 输入建议
+	Query  string     // 获取建议时编辑框的内容, 可用 MatchRange 找到要高亮的部分
+	Hover  bool       // 是否被鼠标停留或用上下键选中
+}
+
+// SuggestionPainter 输入建议的绘制函数, 每条可见的建议调用一次. 调用前已经绘制好选中时的背景.
+type SuggestionPainter func(ctx *SuggestionPaintContext)
+
+// MatchRange 不区分大小写地查找 query 在 text 中第一次出现的位置, 用于高亮匹配的部分.
+//
+// text: 建议的文本.
+//
+// query: 输入的内容.
+//
+// 返回匹配部分的字节下标 [start, end), 没有匹配或 query 为空时返回 -1, -1.
+func MatchRange(text, query string) (start, end int) {
+	if query == "" {
+		return -1, -1
+	}
+	for i := range text {
+		if n := prefixFold(text[i:], query); n > 0 {
+			return i, i + n
+		}
+	}
+	return -1, -1
+}
+
+// prefixFold 不区分大小写地判断 text 是否以 prefix 开头, 是的话返回 text 中匹配部分的字节长度, 否则返回 0.
+func prefixFold(text, prefix string) int {
+	n := 0
+	for _, p := range prefix {
+		r, size := utf8.DecodeRuneInString(text[n:])
+		if size == 0 || unicode.ToLower(r) != unicode.ToLower(p) {
+			return 0
+		}
+		n += size
+	}
+	return n
+}
+
+// setItems 显示新获取的建议, 不选中任何一条并回到顶部.
+//
+// query: 获取建议时编辑框的内容.
+//
+// items: 输入建议.
+func (s *autocompleteState) setItems(query string, items []Suggestion) {
+	s.query = query
+	s.items = items
+	s.hover = -1
+	s.scrollY = 0
+}
+
+// popupHeight 返回弹出框的高度, 最多显示 maxRows 行, 再多就滚动.
+func (s *autocompleteState) popupHeight() int32 {
+	rows := int32(len(s.items))
+	if rows > s.maxRows {
+		rows = s.maxRows
+	}
+	return rows*s.itemHeight + s.padding*2
+}
+
+// contentHeight 返回所有建议排列后的总高度, 包括上下间距.
+func (s *autocompleteState) contentHeight() int32 {
+	return int32(len(s.items))*s.itemHeight + s.padding*2
+}
+
+// scrollTo 设置垂直滚动位置, 会限制在有效范围内. 返回滚动位置是否改变了.
+//
+// y: 滚动位置.
+//
+// height: 弹出框高度.
+func (s *autocompleteState) scrollTo(y, height int32) bool {
+	maxY := s.contentHeight() - height
+	if y > maxY {
+		y = maxY
+	}
+	if y < 0 {
+		y = 0
+	}
+	if y == s.scrollY {
+		return false
+	}
+	s.scrollY = y
+	return true
+}
+
+// itemRect 返回建议在弹出框中的坐标, 已减去滚动位置.
+//
+// i: 建议的下标.
+//
+// width: 弹出框宽度.
+func (s *autocompleteState) itemRect(i int, width int32) Rect {
+	y := s.padding + int32(i)*s.itemHeight - s.scrollY
+	return Rect{Top: y, Right: width, Bottom: y + s.itemHeight}
+}
+
+// hitTest 返回坐标所在的建议下标, 不在建议上时返回 -1.
+//
+// y: 弹出框中的纵坐标.
+func (s *autocompleteState) hitTest(y int32) int {
+	y += s.scrollY - s.padding
+	if y < 0 {
+		return -1
+	}
+	i := int(y / s.itemHeight)
+	if i >= len(s.items) {
+		return -1
+	}
+	return i
+}
+
+// moveHover 用上下键移动选中的建议, 到头后不再移动, 并滚动到能完整看到它的位置. 返回是否需要重绘.
+//
+// delta: 向下移动为 1, 向上移动为 -1.
+//
+// height: 弹出框高度.
+func (s *autocompleteState) moveHover(delta int, height int32) bool {
+	if len(s.items) == 0 {
+		return false
+	}
+	i := s.hover + delta
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(s.items) {
+		i = len(s.items) - 1
+	}
+	changed := i != s.hover
+	s.hover = i
+
+	rc := s.itemRect(i, 0)
+	if rc.Top < s.padding {
+		changed = s.scrollTo(s.scrollY+rc.Top-s.padding, height) || changed
+	} else if rc.Bottom > height-s.padding {
+		changed = s.scrollTo(s.scrollY+rc.Bottom-height+s.padding, height) || changed
+	}
+	return changed
+}
+
+// 输入建议弹出框绘制事件. 只绘制可见的建议, 超出时绘制滚动条.
+func onDrawAutocomplete(ctx *PaintContext) {
+	s, ok := ctx.state.(*autocompleteState)
+	if !ok {
+		return
+	}
+	cv := ctx.Canvas
+	theme := ctx.Theme
+	width, height := ctx.Width, ctx.Height
+	rc := Rect{Right: width, Bottom: height}
+
+	// 绘制边框和背景
+	cv.SetBrushColor(theme.BackgroundColor)
+	cv.FillRoundRect(rc, s.round)
+	cv.SetBrushColor(theme.BorderColorLight)
+	cv.DrawRoundRect(rc, s.round)
+
+	painter := s.itemPainter
+	if painter == nil {
+		painter = drawSuggestion
+	}
+	first := s.hitTest(0)
+	if first < 0 {
+		first = 0
+	}
+	for i := first; i < len(s.items); i++ {
+		item := s.itemRect(i, width)
+		if item.Top >= height {
+			break
+		}
+		if i == s.hover {
+			cv.SetBrushColor(theme.BackgroundColorBase)
+			cv.FillRoundRect(Rect{Left: 1, Top: item.Top, Right: width - 1, Bottom: item.Bottom}, 0)
+		}
+		painter(&SuggestionPaintContext{
+			Canvas: cv,
+			Theme:  theme,
+			Rect:   item,
+			Item:   s.items[i],
+			Query:  s.query,
+			Hover:  i == s.hover,
+		})
+	}
+
+	// 内容超出时绘制滚动条
+	if content := s.contentHeight(); content > height {
+		thumb := height * height / content
+		if thumb < 20 {
+			thumb = 20
+		}
+		top := s.scrollY * (height - thumb) / (content - height)
+		bar := Rect{Left: width - s.scrollBar - 2, Top: top + 2, Right: width - 2, Bottom: top + thumb - 2}
+		cv.SetBrushColor(theme.BorderColorBase)
+		cv.FillRoundRect(bar, s.scrollBar/2)
+	}
+}
+
+// drawSuggestion 默认的输入建议绘制函数. 文字左对齐, 与编辑框的文字对齐, 匹配输入内容的部分使用主题颜色, 放不下时省略.
+func drawSuggestion(ctx *SuggestionPaintContext) {
+	cv := ctx.Canvas
+	theme := ctx.Theme
+	rc := ctx.Rect
+	rc.Left += theme.PaddingInput
+	rc.Right -= theme.PaddingInput
+
+	text := truncateText(cv, ctx.Item.Value, rc.Width())
+	parts := [3]string{text}
+	if start, end := MatchRange(text, ctx.Query); start >= 0 {
+		parts = [3]string{text[:start], text[start:end], text[end:]}
+	}
+	colors := [3]uint32{theme.ColorTextRegular, theme.ColorPrimary, theme.ColorTextRegular}
+	cv.SetTextAlign(TextAlign_VCenter | TextAlign_NoWrap)
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		cv.SetBrushColor(colors[i])
+		cv.DrawText(part, rc)
+		rc.Left += cv.TextSize(part, 0).CX
+	}
+}
@@ -0,0 +1,65 @@
+package eui
+
+import "testing"
+
+func Test_MatchRange(t *testing.T) {
+	tests := []struct {
+		text, query string
+		start, end  int
+	}{
+		{"Golang", "lang", 2, 6},
+		{"Golang", "GO", 0, 2},       // 不区分大小写
+		{"三全鲜食（北新泾店）", "北新", 15, 21}, // 字节下标
+		{"ÄPFEL", "äp", 0, 3},
+		{"Golang", "rust", -1, -1},
+		{"Golang", "", -1, -1},
+	}
+	for _, tt := range tests {
+		start, end := MatchRange(tt.text, tt.query)
+		if start != tt.start || end != tt.end {
+			t.Errorf("MatchRange(%q, %q) = %d, %d, want %d, %d", tt.text, tt.query, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func Test_autocompleteState_moveHover(t *testing.T) {
+	s := &autocompleteState{itemHeight: 30, padding: 5, maxRows: 3, hover: -1}
+	s.setItems("a", make([]Suggestion, 5))
+	height := s.popupHeight()
+	if height != 100 {
+		t.Fatalf("popupHeight = %d, want 100", height)
+	}
+
+	// 第一次按下方向键选中第一条
+	if !s.moveHover(1, height) || s.hover != 0 || s.scrollY != 0 {
+		t.Errorf("first down: hover %d scrollY %d", s.hover, s.scrollY)
+	}
+	// 选中第四条时滚动到它完整显示在底部
+	s.moveHover(1, height)
+	s.moveHover(1, height)
+	s.moveHover(1, height)
+	if s.hover != 3 || s.scrollY != 30 {
+		t.Errorf("scroll down: hover %d scrollY %d, want 3 30", s.hover, s.scrollY)
+	}
+	if i := s.hitTest(94); i != 3 {
+		t.Errorf("hitTest(94) = %d, want 3", i)
+	}
+	// 到底后不再移动
+	s.moveHover(1, height)
+	if s.moveHover(1, height) || s.hover != 4 || s.scrollY != 60 {
+		t.Errorf("at bottom: hover %d scrollY %d, want 4 60", s.hover, s.scrollY)
+	}
+	// 向上移动到看不到的那条时往回滚动
+	s.moveHover(-1, height)
+	s.moveHover(-1, height)
+	s.moveHover(-1, height)
+	if s.hover != 1 || s.scrollY != 30 {
+		t.Errorf("scroll up: hover %d scrollY %d, want 1 30", s.hover, s.scrollY)
+	}
+
+	// 新的建议回到顶部, 不选中
+	s.setItems("b", make([]Suggestion, 2))
+	if s.hover != -1 || s.scrollY != 0 || s.popupHeight() != 70 || s.hitTest(80) != -1 {
+		t.Errorf("setItems: hover %d scrollY %d height %d", s.hover, s.scrollY, s.popupHeight())
+	}
+}
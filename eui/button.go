@@ -1,11 +1,9 @@
+//go:build windows
+
 package eui
 
 import (
-	"strconv"
-	"strings"
-
 	"github.com/twgh/xcgui/ani"
-	"github.com/twgh/xcgui/common"
 	"github.com/twgh/xcgui/widget"
 	"github.com/twgh/xcgui/xc"
 	"github.com/twgh/xcgui/xcc"
@@ -58,7 +56,7 @@ func updateButton(e *Elementui, isChange bool, text string, hParent, hBtn int, o
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
-	if opt.Style < ButtonStyle_Default || opt.Style > ButtonStyle_Text {
+	if _, ok := e.theme.ButtonColors[opt.Style]; !ok {
 		opt.Style = ButtonStyle_Default
 	}
 	if !isChange && opt.Size < ButtonSize_Default || opt.Size > ButtonSize_Mini {
@@ -74,6 +72,7 @@ func updateButton(e *Elementui, isChange bool, text string, hParent, hBtn int, o
 	}
 	btn.SetHandle(hBtn)
 	btn.H = btn.Handle
+	e.addEle(&btn.objBase, &buttonState{})
 	if isChange {
 		// 正确填写 Size 时才改变宽高
 		btn.SetSizeEle(opt.Size)
@@ -87,14 +86,20 @@ func updateButton(e *Elementui, isChange bool, text string, hParent, hBtn int, o
 	// 启用背景透明
 	btn.EnableBkTransparent(true)
 	// 设置圆角大小
-	btn.SetRound(4)
+	btn.SetRound(e.theme.BorderRadiusBase)
 	// 设置是否圆形按钮
 	btn.EnableCircle(opt.IsCircle)
 	// 设置是否朴素按钮
 	btn.EnablePlain(opt.IsPlain)
 	// 设置样式
 	btn.SetStyle(opt.Style)
+	// 设置图标位置和间距
+	btn.SetIconPosition(opt.IconPosition).SetIconGap(opt.IconGap)
+	// 设置文字是否自动换行
+	btn.EnableTextWrap(opt.IsTextWrap)
 
+	// 图标大小和颜色要在设置图标前设置, 这样字体只需要确定一次
+	btn.SetIconSize(opt.IconSize).SetIconColor(opt.IconColor)
 	// 自定义炫彩 svg 句柄优先级最高, 其次是炫彩图片句柄, 再然后是 iconFa
 	if opt.HSvg > 0 && xc.XC_IsHXCGUI(opt.HSvg, xcc.XC_SVG) {
 		btn.SetHSvg(opt.HSvg)
@@ -102,9 +107,9 @@ func updateButton(e *Elementui, isChange bool, text string, hParent, hBtn int, o
 		btn.SetHImage(opt.HImage)
 	} else { // 确定 iconFa 图标和字体类型
 		if opt.IconUnicode > 0 {
-			btn.SetIconUnicode(opt.IconUnicode)
+			btn.SetIconUnicode(opt.IconUnicode, opt.IconStyle)
 		} else if opt.IconHex != "" {
-			btn.SetIconHex(opt.IconHex)
+			btn.SetIconHex(opt.IconHex, opt.IconStyle)
 		} else if opt.Icon != "" {
 			btn.SetIconName(opt.Icon)
 		}
@@ -112,6 +117,8 @@ func updateButton(e *Elementui, isChange bool, text string, hParent, hBtn int, o
 
 	// 注册元素绘制事件
 	btn.Event_PAINT1(onDrawEle)
+	// 注册元素销毁事件
+	btn.Event_DESTROY1(onDestroyEle)
 	return btn
 }
 
@@ -123,11 +130,15 @@ func updateButton(e *Elementui, isChange bool, text string, hParent, hBtn int, o
 //
 // text: 同时更改加载中按钮的文本, on 参数为 true 时生效，加载状态结束后自动恢复原文本, 如果为空则不会更改按钮文本.
 func (b *Button) SetLoading(on bool, svgSize int32, text string) *Button {
-	hAni, _ := strconv.Atoi(b.GetProperty("element-hani"))
+	s := b.btnState()
+	if s == nil {
+		return b
+	}
+	hAni := s.hAni
 	if on {
 		b.Enable(!on)
 		// 记录按钮旧文本, 设置新文本
-		b.SetProperty("element-old-text", b.GetText())
+		s.oldText = b.GetText()
 		if text != "" {
 			b.SetText(text)
 		}
@@ -144,27 +155,27 @@ func (b *Button) SetLoading(on bool, svgSize int32, text string) *Button {
 			}
 			xc.XSvg_SetSize(hSvg_loading, svgSize, svgSize)
 			// 记录旧 svg 图标，设置加载中 svg 图标
-			b.SetProperty("element-icon-hsvg-old", b.GetProperty("element-icon-hsvg"))
-			b.SetProperty("element-icon-hsvg", strconv.Itoa(hSvg_loading))
+			s.oldHSvg = s.hSvg
+			s.hSvg = hSvg_loading
 			// 创建动画序列
 			ani1 := ani.NewAnima(hSvg_loading, 0)
 			ani1.Rotate(2000, 360, 0, 0, false)
 			ani1.Run(b.Handle)
-			b.SetProperty("element-hani", strconv.Itoa(ani1.Handle))
+			s.hAni = ani1.Handle
 		}
 	} else { // 销毁动画序列
 		if hAni > 0 && xc.XC_GetObjectType(hAni) == xcc.XC_ANIMATION_SEQUENCE {
 			if xc.XAnima_Release(hAni, true) {
-				b.SetProperty("element-hani", "")
-				hSvg_loading, _ := strconv.Atoi(b.GetProperty("element-icon-hsvg"))
+				s.hAni = 0
+				hSvg_loading := s.hSvg
 				if hSvg_loading > 0 && xc.XC_IsHXCGUI(hSvg_loading, xcc.XC_SVG) {
 					xc.XSvg_Destroy(hSvg_loading)
 					// 还原 svg 图标
-					b.SetProperty("element-icon-hsvg", b.GetProperty("element-icon-hsvg-old"))
+					s.hSvg = s.oldHSvg
 				}
 			}
 		}
-		b.SetText(b.GetProperty("element-old-text"))
+		b.SetText(s.oldText)
 		b.Enable(!on)
 	}
 	b.Redraw(false)
@@ -199,58 +210,69 @@ func (b *Button) SetSizeEle(size int) *Button {
 //   - 4 = warning
 //   - 5 = danger
 //   - 6 = text
+//   - 其它 = 在 Theme.ButtonColors 中添加的自定义样式, 绘制方式与彩色按钮相同
 func (b *Button) SetStyle(style int) *Button {
-	// 选择不同的绘制事件
-	var funcDrawEle, bgColors, textColors, borderColors, textcolor string
-	if b.IsPlain() && style != ButtonStyle_Text {
-		if style == ButtonStyle_Default {
-			funcDrawEle = "onDrawButton_Default"
-		} else {
-			funcDrawEle = "onDrawButton_Color_Plain"
-			bgColors = ButtonBgColors_Plain[style]
-			textColors = ButtonTextColors_Plain[style]
-			borderColors = ButtonBorderColors_Plain[style]
-			b.SetProperty("element-text-colors", textColors)
-			b.SetProperty("element-border-colors", borderColors)
-		}
-	} else if !b.IsPlain() && style != ButtonStyle_Text {
-		if style == ButtonStyle_Default {
-			funcDrawEle = "onDrawButton_Default"
-		} else {
-			funcDrawEle = "onDrawButton_Color"
-			textcolor = "4294967295"
-			b.SetProperty("element-text-color", textcolor)
-		}
-		bgColors = ButtonBgColors[style]
-	} else { // 无边框无背景
-		funcDrawEle = "onDrawButton_Text"
+	if s := b.btnState(); s != nil {
+		s.setStyle(b.getTheme(), style)
 	}
-	b.SetProperty("element-func-draw-ele", funcDrawEle)
-	b.SetProperty("element-bg-colors", bgColors)
 	return b
 }
 
-// SetRound 设置按钮的圆角大小, 没有设置时的默认圆角是 4.
+// GetStyle 获取按钮样式, 是 ButtonStyle_ 常量.
+func (b *Button) GetStyle() int {
+	if s := b.btnState(); s != nil {
+		return s.style
+	}
+	return 0
+}
+
+// SetRound 设置按钮的圆角大小, 没有设置时的默认圆角是 Theme.BorderRadiusBase.
 //
 // round: 圆角大小, 小于 1 时为直角.
 func (b *Button) SetRound(round int32) *Button {
 	if round < 0 {
 		round = 0
 	}
-	b.SetProperty("element-round", xc.Itoa(round*b.dpi/96))
+	if s := b.btnState(); s != nil {
+		s.setRound(round * b.dpi / 96)
+	}
 	return b
 }
 
-// GetRound 获取按钮的圆角大小.
+// GetRound 获取按钮的圆角大小. 四个角不同时返回最大的.
 func (b *Button) GetRound() int32 {
-	return xc.Atoi(b.GetProperty("element-round")) * 96 / b.dpi
+	if s := b.btnState(); s != nil {
+		return s.round * 96 / b.dpi
+	}
+	return 0
+}
+
+// SetRoundEx 分别设置按钮四个角的圆角大小, 会覆盖 SetRound 的设置, 内部未重绘.
+//
+// leftTop, rightTop, rightBottom, leftBottom: 左上角, 右上角, 右下角, 左下角的圆角大小, 小于 1 时为直角.
+func (b *Button) SetRoundEx(leftTop, rightTop, rightBottom, leftBottom int32) *Button {
+	corners := Corners{LeftTop: leftTop, RightTop: rightTop, RightBottom: rightBottom, LeftBottom: leftBottom}
+	if s := b.btnState(); s != nil {
+		s.setRoundEx(corners.scale(b.dpi, 96))
+	}
+	return b
+}
+
+// GetRoundEx 获取按钮四个角的圆角大小. 使用 SetRound 设置时四个角相同.
+func (b *Button) GetRoundEx() Corners {
+	if s := b.btnState(); s != nil {
+		return s.corners().scale(96, b.dpi)
+	}
+	return Corners{}
 }
 
 // EnableCircle 设置按钮是否圆形.
 //
 // isCircle: 是否圆形按钮.
 func (b *Button) EnableCircle(isCircle bool) *Button {
-	b.SetProperty("element-circle", common.BoolToString(isCircle))
+	if s := b.btnState(); s != nil {
+		s.circle = isCircle
+	}
 	return b
 }
 
@@ -258,18 +280,96 @@ func (b *Button) EnableCircle(isCircle bool) *Button {
 //
 // isPlain: 是否朴素按钮.
 func (b *Button) EnablePlain(isPlain bool) *Button {
-	b.SetProperty("element-plain", common.BoolToString(isPlain))
+	if s := b.btnState(); s != nil {
+		s.plain = isPlain
+	}
 	return b
 }
 
 // IsCircle 是否为圆形按钮.
 func (b *Button) IsCircle() bool {
-	return b.GetProperty("element-circle") == "true"
+	if s := b.btnState(); s != nil {
+		return s.circle
+	}
+	return false
 }
 
 // IsPlain 是否为朴素按钮.
 func (b *Button) IsPlain() bool {
-	return b.GetProperty("element-plain") == "true"
+	if s := b.btnState(); s != nil {
+		return s.plain
+	}
+	return false
+}
+
+// SetIconPosition 设置图标相对文字的位置, 对 Font Awesome 图标, hsvg 和 himage 都有效.
+//
+// position: 图标位置, 可使用常量: ButtonIconPosition_.
+//   - 0 = 左边
+//   - 1 = 右边
+//   - 2 = 上边, 按钮需要足够的高度放下图标和文字
+func (b *Button) SetIconPosition(position int) *Button {
+	if position < ButtonIconPosition_Left || position > ButtonIconPosition_Top {
+		position = ButtonIconPosition_Left
+	}
+	if s := b.btnState(); s != nil {
+		s.iconPosition = position
+	}
+	return b
+}
+
+// GetIconPosition 获取图标位置, 是 ButtonIconPosition_ 常量.
+func (b *Button) GetIconPosition() int {
+	if s := b.btnState(); s != nil {
+		return s.iconPosition
+	}
+	return 0
+}
+
+// SetIconGap 设置图标和文字的间距, 只有图标或只有文字时不使用.
+//
+// gap: 间距, 小于 1 时使用 Theme.SpaceIconText.
+func (b *Button) SetIconGap(gap int32) *Button {
+	if gap < 0 {
+		gap = 0
+	}
+	if s := b.btnState(); s != nil {
+		s.iconGap = gap * b.dpi / 96
+	}
+	return b
+}
+
+// GetIconGap 获取图标和文字的间距, 没有设置时返回 0.
+func (b *Button) GetIconGap() int32 {
+	if s := b.btnState(); s != nil {
+		return s.iconGap * 96 / b.dpi
+	}
+	return 0
+}
+
+// EnableTextWrap 设置文字太长时是否自动换行, 内部未重绘.
+//   - 不换行时文字显示为一行, 超出按钮的部分被裁掉. 换行后行数超出按钮高度时最后一行显示为省略号.
+//   - 文字中的'\n'总会换行.
+//
+// isWrap: 是否自动换行.
+func (b *Button) EnableTextWrap(isWrap bool) *Button {
+	if s := b.btnState(); s != nil {
+		s.textWrap = isWrap
+	}
+	return b
+}
+
+// IsTextWrap 判断文字太长时是否自动换行.
+func (b *Button) IsTextWrap() bool {
+	if s := b.btnState(); s != nil {
+		return s.textWrap
+	}
+	return false
+}
+
+// btnState 获取按钮的状态. 按钮没有记录状态时返回 nil.
+func (b *Button) btnState() *buttonState {
+	return getButtonState(b.H)
 }
 
 // ButtonOption 按钮选项.
@@ -287,11 +387,18 @@ type ButtonOption struct {
 	// Font Wesome 图标对应的 Unicode 码点十六进制文本, 如'f1b0'相当于'fa-solid fa-paw'.
 	// 	- 注意: HSvg, HImage, IconUnicode, IconHex, Icon 这几个参数只需要填一个即可, 填多个的话, 生效顺序优先级为: HSvg > HImage > IconUnicode > IconHex > Icon.
 	IconHex string
+	// 使用 IconUnicode 或 IconHex 时优先使用的风格, 如'fa-regular'. 同一个码点可能有多个风格, 如 address-book 有'fa-solid'和'fa-regular'.
+	//  - 为空或图标没有这个风格时按'fa-solid', 'fa-brands', 'fa-regular'的顺序选择.
+	IconStyle string
 	// Font Wesome 图标名.
 	//  - 如'fa-solid fa-paw', 前面是风格, 后面是图标名, 用空格分开, 其中风格可省略, 没有风格时会自动根据'fa-solid', 'fa-brands', 'fa-regular'的顺序尝试添加风格.
 	//  - 图标大全: https://fa6.dashgame.com, 在网页里点导航栏图标, 然后点免费, 可筛选出 2000+ 免费图标, 点击图标会复制完整风格+图标名到剪贴板, 可直接使用. 内置 FontAwesome 版本为6.6.0
 	// 	- 注意: HSvg, HImage, IconUnicode, IconHex, Icon 这几个参数只需要填一个即可, 填多个的话, 生效顺序优先级为: HSvg > HImage > IconUnicode > IconHex > Icon.
 	Icon string
+	// 图标大小, 单位与 NewElementui 的 fontSize 相同, 只对 Font Awesome 图标有效. 为 0 时使用 fontSize.
+	IconSize int32
+	// 图标颜色, xc.RGBA 的返回值, 对 Font Awesome 图标和 HSvg 有效. 为 0 时使用默认颜色.
+	IconColor uint32
 
 	X, Y, Width, Height int32
 
@@ -312,6 +419,7 @@ type ButtonOption struct {
 	//  - 4 = warning
 	//  - 5 = danger
 	//  - 6 = text
+	//  - 其它 = 在 Theme.ButtonColors 中添加的自定义样式, 可使用 Theme.NewButtonColors 根据任意颜色计算.
 	Style int
 
 	// 是否为朴素按钮, 默认为 false.
@@ -320,501 +428,14 @@ type ButtonOption struct {
 	// 是否为圆形按钮, 默认为 false.
 	//  - 当 Style 字段 = ButtonStyle_Text 时本字段无效.
 	IsCircle bool
-}
-
-// 按钮尺寸. 已经预设好的.
-
-const (
-	ButtonSize_Default = iota + 1 // 98x40
-	ButtonSize_Mdeium             // 98x36
-	ButtonSize_Small              // 80x32
-	ButtonSize_Mini               // 80x28
-)
-
-// 按钮样式. 已经预设好的.
-
-const (
-	ButtonStyle_Default = iota
-	ButtonStyle_Primary
-	ButtonStyle_Success
-	ButtonStyle_Info
-	ButtonStyle_Warning
-	ButtonStyle_Danger
-	ButtonStyle_Text
-)
-
-// ButtonBgColors 存放按钮不同样式的背景颜色字符串, 不包含朴素按钮的.
-//   - 顺序: Leave, Stay, Down, Check, Disable
-var ButtonBgColors = map[int]string{
-	ButtonStyle_Default: JoinColorString(xc.RGBA(255, 255, 255, 255), xc.RGBA(236, 245, 255, 255), xc.RGBA(236, 245, 255, 255), 0, xc.RGBA(255, 255, 255, 255)),
-	ButtonStyle_Primary: JoinColorString(xc.RGBA(64, 158, 255, 255), xc.RGBA(102, 177, 255, 255), xc.RGBA(58, 142, 230, 255), 0, xc.RGBA(160, 207, 255, 255)),
-	ButtonStyle_Success: JoinColorString(xc.RGBA(103, 194, 58, 255), xc.RGBA(133, 206, 97, 255), xc.RGBA(93, 175, 52, 255), 0, xc.RGBA(179, 225, 157, 255)),
-	ButtonStyle_Info:    JoinColorString(xc.RGBA(144, 147, 153, 255), xc.RGBA(166, 169, 173, 255), xc.RGBA(130, 132, 138, 255), 0, xc.RGBA(200, 201, 204, 255)),
-	ButtonStyle_Warning: JoinColorString(xc.RGBA(230, 162, 60, 255), xc.RGBA(235, 181, 99, 255), xc.RGBA(207, 146, 54, 255), 0, xc.RGBA(243, 209, 158, 255)),
-	ButtonStyle_Danger:  JoinColorString(xc.RGBA(245, 108, 108, 255), xc.RGBA(247, 137, 137, 255), xc.RGBA(221, 97, 97, 255), 0, xc.RGBA(250, 182, 182, 255)),
-}
-
-// ButtonBorderColors_Plain 存放朴素按钮不同样式的边框颜色字符串, 不包含 default 样式的
-//   - 顺序: Leave, Stay, Down, Check, Disable
-var ButtonBorderColors_Plain = map[int]string{
-	ButtonStyle_Primary: JoinColorString(xc.RGBA(179, 216, 255, 255), xc.RGBA(64, 158, 255, 255), xc.RGBA(58, 142, 230, 255), 0, xc.RGBA(217, 236, 255, 255)),
-	ButtonStyle_Success: JoinColorString(xc.RGBA(194, 231, 176, 255), xc.RGBA(103, 194, 58, 255), xc.RGBA(93, 175, 52, 255), 0, xc.RGBA(225, 243, 216, 255)),
-	ButtonStyle_Info:    JoinColorString(xc.RGBA(211, 212, 214, 255), xc.RGBA(144, 147, 153, 255), xc.RGBA(130, 132, 138, 255), 0, xc.RGBA(233, 233, 235, 255)),
-	ButtonStyle_Warning: JoinColorString(xc.RGBA(245, 218, 177, 255), xc.RGBA(230, 162, 60, 255), xc.RGBA(207, 146, 54, 255), 0, xc.RGBA(250, 236, 216, 255)),
-	ButtonStyle_Danger:  JoinColorString(xc.RGBA(251, 196, 196, 255), xc.RGBA(245, 108, 108, 255), xc.RGBA(221, 97, 97, 255), 0, xc.RGBA(253, 226, 226, 255)),
-}
 
-// ButtonBgColors_Plain 存放朴素按钮不同样式的背景颜色字符串, 不包含 default 样式的
-//   - 顺序: Leave, Stay, Down, Check, Disable
-var ButtonBgColors_Plain = map[int]string{
-	ButtonStyle_Primary: JoinColorString(xc.RGBA(236, 245, 255, 255), xc.RGBA(64, 158, 255, 255), xc.RGBA(58, 142, 230, 255), 0, xc.RGBA(236, 245, 255, 255)),
-	ButtonStyle_Success: JoinColorString(xc.RGBA(240, 249, 235, 255), xc.RGBA(103, 194, 58, 255), xc.RGBA(93, 175, 52, 255), 0, xc.RGBA(240, 249, 235, 255)),
-	ButtonStyle_Info:    JoinColorString(xc.RGBA(244, 244, 245, 255), xc.RGBA(144, 147, 153, 255), xc.RGBA(130, 132, 138, 255), 0, xc.RGBA(244, 244, 245, 255)),
-	ButtonStyle_Warning: JoinColorString(xc.RGBA(253, 246, 236, 255), xc.RGBA(230, 162, 60, 255), xc.RGBA(207, 146, 54, 255), 0, xc.RGBA(253, 246, 236, 255)),
-	ButtonStyle_Danger:  JoinColorString(xc.RGBA(254, 240, 240, 255), xc.RGBA(245, 108, 108, 255), xc.RGBA(221, 97, 97, 255), 0, xc.RGBA(254, 240, 240, 255)),
-}
-
-// ButtonTextColors_Plain 存放朴素按钮不同样式的字体颜色字符串, 不包含 default 样式的
-//   - 顺序: Leave, Stay, Down, Check, Disable
-var ButtonTextColors_Plain = map[int]string{
-	ButtonStyle_Primary: JoinColorString(xc.RGBA(64, 158, 255, 255), xc.RGBA(255, 255, 255, 255), xc.RGBA(255, 255, 255, 255), 0, xc.RGBA(140, 197, 255, 255)),
-	ButtonStyle_Success: JoinColorString(xc.RGBA(103, 194, 58, 255), xc.RGBA(255, 255, 255, 255), xc.RGBA(255, 255, 255, 255), 0, xc.RGBA(164, 218, 137, 255)),
-	ButtonStyle_Info:    JoinColorString(xc.RGBA(144, 147, 153, 255), xc.RGBA(255, 255, 255, 255), xc.RGBA(255, 255, 255, 255), 0, xc.RGBA(188, 190, 194, 255)),
-	ButtonStyle_Warning: JoinColorString(xc.RGBA(230, 162, 60, 255), xc.RGBA(255, 255, 255, 255), xc.RGBA(255, 255, 255, 255), 0, xc.RGBA(240, 199, 138, 255)),
-	ButtonStyle_Danger:  JoinColorString(xc.RGBA(245, 108, 108, 255), xc.RGBA(255, 255, 255, 255), xc.RGBA(255, 255, 255, 255), 0, xc.RGBA(249, 167, 167, 255)),
-}
-
-// 默认按钮和朴素默认按钮 style 0
-func onDrawButton_Default(hEle int, hDraw int, pbHandled *bool) int {
-	*pbHandled = true
-	var rc xc.RECT
-	rc.Right = xc.XEle_GetWidth(hEle)
-	rc.Bottom = xc.XEle_GetHeight(hEle)
-	xc.XDraw_EnableSmoothingMode(hDraw, true)
-
-	var textColor, borderColor, bgColor uint32
-	nState := xc.XBtn_GetStateEx(hEle)
-	isPlain := xc.XC_GetProperty(hEle, "element-plain") == "true"
-	if isPlain { // 朴素按钮
-		bgColor = xcc.COLOR_WHITE
-	} else {
-		if bgColorsText := xc.XC_GetProperty(hEle, "element-bg-colors"); bgColorsText != "" {
-			colors := strings.Split(bgColorsText, ",")
-			if int(nState) < len(colors) {
-				bgColor = common.AtoUint32(colors[nState])
-			}
-		}
-	}
-
-	switch nState {
-	case xcc.Button_State_Leave:
-		borderColor = xc.RGBA(220, 223, 229, 255)
-		textColor = xc.RGBA(96, 98, 102, 255)
-	case xcc.Button_State_Stay:
-		borderColor = xc.RGBA(198, 226, 255, 255)
-		textColor = xc.RGBA(64, 158, 255, 255)
-	case xcc.Button_State_Down:
-		borderColor = xc.RGBA(58, 142, 230, 255)
-		textColor = xc.RGBA(58, 142, 230, 255)
-	case xcc.Button_State_Disable:
-		borderColor = xc.RGBA(235, 238, 245, 255)
-		textColor = xc.RGBA(192, 196, 204, 255)
-	}
-
-	var rc2 xc.RECT
-	round := xc.Atoi(xc.XC_GetProperty(hEle, "element-round"))
-	if xc.XC_GetProperty(hEle, "element-circle") == "true" { // 圆形按钮
-		xc.XDraw_SetBrushColor(hDraw, borderColor)
-		xc.XDraw_DrawEllipse(hDraw, &rc)
-		rc2.Top = 1
-		rc2.Left = 1
-		rc2.Right = rc.Right - 1
-		rc2.Bottom = rc.Bottom - 1
-		xc.XDraw_SetBrushColor(hDraw, bgColor)
-		xc.XDraw_FillEllipse(hDraw, &rc2)
-	} else { // 圆角按钮
-		xc.XDraw_SetBrushColor(hDraw, borderColor)
-		xc.XDraw_DrawRoundRect(hDraw, &rc, round, round)
-		rc2.Top = 1
-		rc2.Left = 1
-		rc2.Right = rc.Right - 1
-		rc2.Bottom = rc.Bottom - 1
-		xc.XDraw_SetBrushColor(hDraw, bgColor)
-		xc.XDraw_FillRoundRect(hDraw, &rc2, round, round)
-	}
-	xc.XDraw_SetBrushColor(hDraw, textColor)
-
-	btnText := xc.XBtn_GetText(hEle)
-	if hSvg, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-icon-hsvg")); hSvg > 0 && xc.XC_IsHXCGUI(hSvg, xcc.XC_SVG) {
-		xc.XSvg_SetUserFillColor(hSvg, textColor, true)
-		if btnText == "" { // 只有图标
-			xc.XDraw_DrawSvg(hDraw, hSvg, (rc.Right-xc.XSvg_GetWidth(hSvg))/2, (rc.Bottom-xc.XSvg_GetHeight(hSvg))/2)
-			return 0
-		}
-
-		// 图标+文字
-		var defaultFontShowSize xc.SIZE
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), xc.XC_GetDefaultFont(), &defaultFontShowSize)
-		svgWidth := xc.XSvg_GetWidth(hSvg)
-		var space int32 = 4 // 图标和文字之间的间距
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left-defaultFontShowSize.CX-svgWidth-space)/2, 0, svgWidth, 0)
-		xc.XDraw_DrawSvg(hDraw, hSvg, rc3.Left, (rc.Bottom-xc.XSvg_GetHeight(hSvg))/2)
-
-		rc3 = xc.OffsetRect(rc3, svgWidth+space, 0, 0, 0)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if hImage, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-icon-himage")); hImage > 0 && xc.XC_IsHXCGUI(hImage, xcc.XC_IMAGE_FRAME) {
-		if btnText == "" { // 只有图标
-			xc.XDraw_Image(hDraw, hImage, (rc.Right-xc.XImage_GetWidth(hImage))/2, (rc.Bottom-xc.XImage_GetHeight(hImage))/2)
-			return 0
-		}
-
-		// 图标+文字
-		var defaultFontShowSize xc.SIZE
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), xc.XC_GetDefaultFont(), &defaultFontShowSize)
-		imgWidth := xc.XImage_GetWidth(hImage)
-		var space int32 = 4 // 图标和文字之间的间距
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left-defaultFontShowSize.CX-imgWidth-space)/2, 0, imgWidth, 0)
-		xc.XDraw_Image(hDraw, hImage, rc3.Left, (rc.Bottom-xc.XImage_GetHeight(hImage))/2)
-
-		rc3 = xc.OffsetRect(rc3, imgWidth+space, 0, 0, 0)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_SetBrushColor(hDraw, textColor)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if iconFa := xc.XC_GetProperty(hEle, "element-icon-fa"); iconFa != "" {
-		hFontAwesome, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-hfontawesome"))
-		xc.XDraw_SetFont(hDraw, hFontAwesome)
-		if btnText == "" { // 只有图标
-			xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap|xcc.TextAlignFlag_Center)
-			xc.XDraw_DrawText(hDraw, iconFa, &rc)
-			return 0
-		}
-
-		// 图标+文字
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		var defaultFontShowSize xc.SIZE
-		defaultFont := xc.XC_GetDefaultFont()
-		hFontAwesomeShowSizeCx := xc.Atoi(xc.XC_GetProperty(hEle, "element-hfontawesome-showsize-cx"))
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), defaultFont, &defaultFontShowSize)
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left)/2-(defaultFontShowSize.CX+hFontAwesomeShowSizeCx)/2, 0, hFontAwesomeShowSizeCx, 0)
-		xc.XDraw_DrawText(hDraw, iconFa, &rc3)
-
-		rc3 = xc.OffsetRect(rc3, hFontAwesomeShowSizeCx, 0, 0, 0)
-		xc.XDraw_SetFont(hDraw, defaultFont)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if btnText != "" { // 纯文本
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap|xcc.TextAlignFlag_Center)
-		xc.XDraw_DrawText(hDraw, btnText, &rc)
-	}
-	return 0
-}
-
-// 彩色按钮 style 1-5
-func onDrawButton_Color(hEle int, hDraw int, pbHandled *bool) int {
-	*pbHandled = true
-	var rc xc.RECT
-	rc.Right = xc.XEle_GetWidth(hEle)
-	rc.Bottom = xc.XEle_GetHeight(hEle)
-	xc.XDraw_EnableSmoothingMode(hDraw, true)
-
-	textColor := common.AtoUint32(xc.XC_GetProperty(hEle, "element-text-color"))
-	var bgColor uint32 // 背景颜色
-	if bgColorsText := xc.XC_GetProperty(hEle, "element-bg-colors"); bgColorsText != "" {
-		colors := strings.Split(bgColorsText, ",")
-		nState := int(xc.XBtn_GetStateEx(hEle))
-		if nState < len(colors) {
-			bgColor = common.AtoUint32(colors[nState])
-		}
-	}
-
-	round := xc.Atoi(xc.XC_GetProperty(hEle, "element-round"))
-	if xc.XC_GetProperty(hEle, "element-circle") == "true" { // 圆形按钮
-		xc.XDraw_SetBrushColor(hDraw, bgColor)
-		xc.XDraw_FillEllipse(hDraw, &rc)
-	} else { // 圆角按钮
-		xc.XDraw_SetBrushColor(hDraw, bgColor)
-		xc.XDraw_FillRoundRect(hDraw, &rc, round, round)
-	}
-	xc.XDraw_SetBrushColor(hDraw, textColor)
-
-	btnText := xc.XBtn_GetText(hEle)
-	if hSvg, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-icon-hsvg")); hSvg > 0 && xc.XC_IsHXCGUI(hSvg, xcc.XC_SVG) {
-		xc.XSvg_SetUserFillColor(hSvg, textColor, true)
-		if btnText == "" { // 只有图标
-			xc.XDraw_DrawSvg(hDraw, hSvg, (rc.Right-xc.XSvg_GetWidth(hSvg))/2, (rc.Bottom-xc.XSvg_GetHeight(hSvg))/2)
-			return 0
-		}
-
-		// 图标+文字
-		var defaultFontShowSize xc.SIZE
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), xc.XC_GetDefaultFont(), &defaultFontShowSize)
-		svgWidth := xc.XSvg_GetWidth(hSvg)
-		var space int32 = 4 // 图标和文字之间的间距
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left-defaultFontShowSize.CX-svgWidth-space)/2, 0, svgWidth, 0)
-		xc.XDraw_DrawSvg(hDraw, hSvg, rc3.Left, (rc.Bottom-xc.XSvg_GetHeight(hSvg))/2)
-
-		rc3 = xc.OffsetRect(rc3, svgWidth+space, 0, 0, 0)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if hImage, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-icon-himage")); hImage > 0 && xc.XC_IsHXCGUI(hImage, xcc.XC_IMAGE_FRAME) {
-		if btnText == "" { // 只有图标
-			xc.XDraw_Image(hDraw, hImage, (rc.Right-xc.XImage_GetWidth(hImage))/2, (rc.Bottom-xc.XImage_GetHeight(hImage))/2)
-			return 0
-		}
-
-		// 图标+文字
-		var defaultFontShowSize xc.SIZE
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), xc.XC_GetDefaultFont(), &defaultFontShowSize)
-		imgWidth := xc.XImage_GetWidth(hImage)
-		var space int32 = 4 // 图标和文字之间的间距
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left-defaultFontShowSize.CX-imgWidth-space)/2, 0, imgWidth, 0)
-		xc.XDraw_Image(hDraw, hImage, rc3.Left, (rc.Bottom-xc.XImage_GetHeight(hImage))/2)
-
-		rc3 = xc.OffsetRect(rc3, imgWidth+space, 0, 0, 0)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_SetBrushColor(hDraw, textColor)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if iconFa := xc.XC_GetProperty(hEle, "element-icon-fa"); iconFa != "" {
-		hFontAwesome, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-hfontawesome"))
-		xc.XDraw_SetFont(hDraw, hFontAwesome)
-		if btnText == "" { // 只有图标
-			xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap|xcc.TextAlignFlag_Center)
-			xc.XDraw_DrawText(hDraw, iconFa, &rc)
-			return 0
-		}
-
-		// 图标+文字
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		var defaultFontShowSize xc.SIZE
-		defaultFont := xc.XC_GetDefaultFont()
-		hFontAwesomeShowSizeCx := xc.Atoi(xc.XC_GetProperty(hEle, "element-hfontawesome-showsize-cx"))
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), defaultFont, &defaultFontShowSize)
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left)/2-(defaultFontShowSize.CX+hFontAwesomeShowSizeCx)/2, 0, hFontAwesomeShowSizeCx, 0)
-		xc.XDraw_DrawText(hDraw, iconFa, &rc3)
-
-		rc3 = xc.OffsetRect(rc3, hFontAwesomeShowSizeCx, 0, 0, 0)
-		xc.XDraw_SetFont(hDraw, defaultFont)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if btnText != "" { // 纯文本
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap|xcc.TextAlignFlag_Center)
-		xc.XDraw_DrawText(hDraw, btnText, &rc)
-	}
-	return 0
-}
-
-// 朴素彩色按钮 style 1-5
-func onDrawButton_Color_Plain(hEle int, hDraw int, pbHandled *bool) int {
-	*pbHandled = true
-	var rc xc.RECT
-	rc.Right = xc.XEle_GetWidth(hEle)
-	rc.Bottom = xc.XEle_GetHeight(hEle)
-	xc.XDraw_EnableSmoothingMode(hDraw, true)
-
-	nState := xc.XBtn_GetStateEx(hEle)
-	var bgColor, textColor, borderColor uint32
-	if bgColorsText := xc.XC_GetProperty(hEle, "element-bg-colors"); bgColorsText != "" {
-		colors := strings.Split(bgColorsText, ",")
-		if int(nState) < len(colors) {
-			bgColor = common.AtoUint32(colors[nState])
-		}
-	}
-	if textColorsText := xc.XC_GetProperty(hEle, "element-text-colors"); textColorsText != "" {
-		colors := strings.Split(textColorsText, ",")
-		if int(nState) < len(colors) {
-			textColor = common.AtoUint32(colors[nState])
-		}
-	}
-	if borderColorsText := xc.XC_GetProperty(hEle, "element-border-colors"); borderColorsText != "" {
-		colors := strings.Split(borderColorsText, ",")
-		if int(nState) < len(colors) {
-			borderColor = common.AtoUint32(colors[nState])
-		}
-	}
-
-	var rc2 xc.RECT
-	switch nState {
-	case xcc.Button_State_Leave:
-		rc2.Top = 1
-		rc2.Left = 1
-		rc2.Right = rc.Right - 1
-		rc2.Bottom = rc.Bottom - 1
-	case xcc.Button_State_Stay:
-		rc2 = rc
-	case xcc.Button_State_Down:
-		rc2 = rc
-	case xcc.Button_State_Disable:
-		rc2.Top = 1
-		rc2.Left = 1
-		rc2.Right = rc.Right - 1
-		rc2.Bottom = rc.Bottom - 1
-	}
-
-	round := xc.Atoi(xc.XC_GetProperty(hEle, "element-round"))
-	if xc.XC_GetProperty(hEle, "element-circle") == "true" { // 圆形按钮
-		xc.XDraw_SetBrushColor(hDraw, borderColor)
-		xc.XDraw_DrawEllipse(hDraw, &rc)
-		xc.XDraw_SetBrushColor(hDraw, bgColor)
-		xc.XDraw_FillEllipse(hDraw, &rc)
-	} else { // 圆角按钮
-		xc.XDraw_SetBrushColor(hDraw, borderColor)
-		xc.XDraw_DrawRoundRect(hDraw, &rc, round, round)
-		xc.XDraw_SetBrushColor(hDraw, bgColor)
-		xc.XDraw_FillRoundRect(hDraw, &rc2, round, round)
-	}
-	xc.XDraw_SetBrushColor(hDraw, textColor)
-
-	btnText := xc.XBtn_GetText(hEle)
-	if hSvg, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-icon-hsvg")); hSvg > 0 && xc.XC_IsHXCGUI(hSvg, xcc.XC_SVG) {
-		xc.XSvg_SetUserFillColor(hSvg, textColor, true)
-		if btnText == "" { // 只有图标
-			xc.XDraw_DrawSvg(hDraw, hSvg, (rc.Right-xc.XSvg_GetWidth(hSvg))/2, (rc.Bottom-xc.XSvg_GetHeight(hSvg))/2)
-			return 0
-		}
-
-		// 图标+文字
-		var defaultFontShowSize xc.SIZE
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), xc.XC_GetDefaultFont(), &defaultFontShowSize)
-		svgWidth := xc.XSvg_GetWidth(hSvg)
-		var space int32 = 4 // 图标和文字之间的间距
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left-defaultFontShowSize.CX-svgWidth-space)/2, 0, svgWidth, 0)
-		xc.XDraw_DrawSvg(hDraw, hSvg, rc3.Left, (rc.Bottom-xc.XSvg_GetHeight(hSvg))/2)
-
-		rc3 = xc.OffsetRect(rc3, svgWidth+space, 0, 0, 0)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if hImage, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-icon-himage")); hImage > 0 && xc.XC_IsHXCGUI(hImage, xcc.XC_IMAGE_FRAME) {
-		if btnText == "" { // 只有图标
-			xc.XDraw_Image(hDraw, hImage, (rc.Right-xc.XImage_GetWidth(hImage))/2, (rc.Bottom-xc.XImage_GetHeight(hImage))/2)
-			return 0
-		}
-
-		// 图标+文字
-		var defaultFontShowSize xc.SIZE
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), xc.XC_GetDefaultFont(), &defaultFontShowSize)
-		imgWidth := xc.XImage_GetWidth(hImage)
-		var space int32 = 4 // 图标和文字之间的间距
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left-defaultFontShowSize.CX-imgWidth-space)/2, 0, imgWidth, 0)
-		xc.XDraw_Image(hDraw, hImage, rc3.Left, (rc.Bottom-xc.XImage_GetHeight(hImage))/2)
-
-		rc3 = xc.OffsetRect(rc3, imgWidth+space, 0, 0, 0)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_SetBrushColor(hDraw, textColor)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if iconFa := xc.XC_GetProperty(hEle, "element-icon-fa"); iconFa != "" {
-		hFontAwesome, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-hfontawesome"))
-		xc.XDraw_SetFont(hDraw, hFontAwesome)
-		if btnText == "" { // 只有图标
-			xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap|xcc.TextAlignFlag_Center)
-			xc.XDraw_DrawText(hDraw, iconFa, &rc)
-			return 0
-		}
-
-		// 图标+文字
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		var defaultFontShowSize xc.SIZE
-		defaultFont := xc.XC_GetDefaultFont()
-		hFontAwesomeShowSizeCx := xc.Atoi(xc.XC_GetProperty(hEle, "element-hfontawesome-showsize-cx"))
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), defaultFont, &defaultFontShowSize)
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left)/2-(defaultFontShowSize.CX+hFontAwesomeShowSizeCx)/2, 0, hFontAwesomeShowSizeCx, 0)
-		xc.XDraw_DrawText(hDraw, iconFa, &rc3)
-
-		rc3 = xc.OffsetRect(rc3, hFontAwesomeShowSizeCx, 0, 0, 0)
-		xc.XDraw_SetFont(hDraw, defaultFont)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if btnText != "" { // 纯文本
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap|xcc.TextAlignFlag_Center)
-		xc.XDraw_DrawText(hDraw, btnText, &rc)
-	}
-	return 0
-}
-
-// 无边框无背景按钮 style 6
-func onDrawButton_Text(hEle int, hDraw int, pbHandled *bool) int {
-	*pbHandled = true
-	var rc xc.RECT
-	rc.Right = xc.XEle_GetWidth(hEle)
-	rc.Bottom = xc.XEle_GetHeight(hEle)
-	xc.XDraw_EnableSmoothingMode(hDraw, true)
-
-	var textColor uint32 // 文本颜色
-	nState := xc.XBtn_GetStateEx(hEle)
-	switch nState {
-	case xcc.Button_State_Leave:
-		textColor = xc.RGBA(64, 158, 255, 255)
-	case xcc.Button_State_Stay:
-		textColor = xc.RGBA(102, 177, 255, 255)
-	case xcc.Button_State_Down:
-		textColor = xc.RGBA(58, 142, 230, 255)
-	case xcc.Button_State_Disable:
-		textColor = xc.RGBA(192, 196, 204, 255)
-	}
-	xc.XDraw_SetBrushColor(hDraw, textColor)
-
-	btnText := xc.XBtn_GetText(hEle)
-	if hSvg, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-icon-hsvg")); hSvg > 0 && xc.XC_IsHXCGUI(hSvg, xcc.XC_SVG) {
-		xc.XSvg_SetUserFillColor(hSvg, textColor, true)
-		if btnText == "" { // 只有图标
-			xc.XDraw_DrawSvg(hDraw, hSvg, (rc.Right-xc.XSvg_GetWidth(hSvg))/2, (rc.Bottom-xc.XSvg_GetHeight(hSvg))/2)
-			return 0
-		}
-
-		// 图标+文字
-		var defaultFontShowSize xc.SIZE
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), xc.XC_GetDefaultFont(), &defaultFontShowSize)
-		svgWidth := xc.XSvg_GetWidth(hSvg)
-		var space int32 = 4 // 图标和文字之间的间距
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left-defaultFontShowSize.CX-svgWidth-space)/2, 0, svgWidth, 0)
-		xc.XDraw_DrawSvg(hDraw, hSvg, rc3.Left, (rc.Bottom-xc.XSvg_GetHeight(hSvg))/2)
-
-		rc3 = xc.OffsetRect(rc3, svgWidth+space, 0, 0, 0)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if hImage, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-icon-himage")); hImage > 0 && xc.XC_IsHXCGUI(hImage, xcc.XC_IMAGE_FRAME) {
-		if btnText == "" { // 只有图标
-			xc.XDraw_Image(hDraw, hImage, (rc.Right-xc.XImage_GetWidth(hImage))/2, (rc.Bottom-xc.XImage_GetHeight(hImage))/2)
-			return 0
-		}
-
-		// 图标+文字
-		var defaultFontShowSize xc.SIZE
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), xc.XC_GetDefaultFont(), &defaultFontShowSize)
-		imgWidth := xc.XImage_GetWidth(hImage)
-		var space int32 = 4 // 图标和文字之间的间距
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left-defaultFontShowSize.CX-imgWidth-space)/2, 0, imgWidth, 0)
-		xc.XDraw_Image(hDraw, hImage, rc3.Left, (rc.Bottom-xc.XImage_GetHeight(hImage))/2)
-
-		rc3 = xc.OffsetRect(rc3, imgWidth+space, 0, 0, 0)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_SetBrushColor(hDraw, textColor)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if iconFa := xc.XC_GetProperty(hEle, "element-icon-fa"); iconFa != "" {
-		hFontAwesome, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-hfontawesome"))
-		xc.XDraw_SetFont(hDraw, hFontAwesome)
-		if btnText == "" { // 只有图标
-			xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap|xcc.TextAlignFlag_Center)
-			xc.XDraw_DrawText(hDraw, iconFa, &rc)
-			return 0
-		}
-
-		// 图标+文字
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		var defaultFontShowSize xc.SIZE
-		defaultFont := xc.XC_GetDefaultFont()
-		hFontAwesomeShowSizeCx := xc.Atoi(xc.XC_GetProperty(hEle, "element-hfontawesome-showsize-cx"))
-		xc.XC_GetTextShowSize(btnText, int32(len(btnText)), defaultFont, &defaultFontShowSize)
-		rc3 := xc.OffsetRect(rc, (rc.Right-rc.Left)/2-(defaultFontShowSize.CX+hFontAwesomeShowSizeCx)/2, 0, hFontAwesomeShowSizeCx, 0)
-		xc.XDraw_DrawText(hDraw, iconFa, &rc3)
-
-		rc3 = xc.OffsetRect(rc3, hFontAwesomeShowSizeCx, 0, 0, 0)
-		xc.XDraw_SetFont(hDraw, defaultFont)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap)
-		xc.XDraw_DrawText(hDraw, btnText, &rc3)
-	} else if btnText != "" { // 纯文本
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap|xcc.TextAlignFlag_Center)
-		xc.XDraw_DrawText(hDraw, btnText, &rc)
-	}
-	return 0
+	// 图标相对文字的位置, 默认为 ButtonIconPosition_Left, 可使用常量: ButtonIconPosition_
+	//  - 0 = 左边
+	//  - 1 = 右边
+	//  - 2 = 上边, 按钮需要足够的高度放下图标和文字
+	IconPosition int
+	// 图标和文字的间距, 为 0 时使用 Theme.SpaceIconText.
+	IconGap int32
+	// 文字太长时是否自动换行, 默认为 false, 此时文字显示为一行, 超出按钮的部分被裁掉. 文字中的'\n'总会换行.
+	IsTextWrap bool
 }
@@ -0,0 +1,249 @@
+package eui
+
+// 按钮尺寸. 已经预设好的.
+
+const (
+	ButtonSize_Default = iota + 1 // 98x40
+	ButtonSize_Mdeium             // 98x36
+	ButtonSize_Small              // 80x32
+	ButtonSize_Mini               // 80x28
+)
+
+// 按钮样式. 已经预设好的.
+
+const (
+	ButtonStyle_Default = iota
+	ButtonStyle_Primary
+	ButtonStyle_Success
+	ButtonStyle_Info
+	ButtonStyle_Warning
+	ButtonStyle_Danger
+	ButtonStyle_Text
+)
+
+// 按钮图标位置.
+
+const (
+	ButtonIconPosition_Left  = iota // 图标在文字左边
+	ButtonIconPosition_Right        // 图标在文字右边, 如'上传 ↑'
+	ButtonIconPosition_Top          // 图标在文字上边, 用于竖排的磁贴按钮
+)
+
+// ButtonBgColors 存放默认主题下按钮不同样式的背景颜色字符串, 不包含朴素按钮的.
+//   - 顺序: Leave, Stay, Down, Check, Disable
+//
+// Deprecated: 绘制时使用的是 Theme.ButtonColors, 修改本变量不会生效, 请修改主题.
+var ButtonBgColors = themeButtonColorStrings(ButtonStyle_Default, func(c ButtonColors) [5]uint32 { return c.Bg })
+
+// ButtonBorderColors_Plain 存放默认主题下朴素按钮不同样式的边框颜色字符串, 不包含 default 样式的
+//   - 顺序: Leave, Stay, Down, Check, Disable
+//
+// Deprecated: 绘制时使用的是 Theme.ButtonColors, 修改本变量不会生效, 请修改主题.
+var ButtonBorderColors_Plain = themeButtonColorStrings(ButtonStyle_Primary, func(c ButtonColors) [5]uint32 { return c.PlainBorder })
+
+// ButtonBgColors_Plain 存放默认主题下朴素按钮不同样式的背景颜色字符串, 不包含 default 样式的
+//   - 顺序: Leave, Stay, Down, Check, Disable
+//
+// Deprecated: 绘制时使用的是 Theme.ButtonColors, 修改本变量不会生效, 请修改主题.
+var ButtonBgColors_Plain = themeButtonColorStrings(ButtonStyle_Primary, func(c ButtonColors) [5]uint32 { return c.PlainBg })
+
+// ButtonTextColors_Plain 存放默认主题下朴素按钮不同样式的字体颜色字符串, 不包含 default 样式的
+//   - 顺序: Leave, Stay, Down, Check, Disable
+//
+// Deprecated: 绘制时使用的是 Theme.ButtonColors, 修改本变量不会生效, 请修改主题.
+var ButtonTextColors_Plain = themeButtonColorStrings(ButtonStyle_Primary, func(c ButtonColors) [5]uint32 { return c.PlainText })
+
+// 从默认主题中取出按钮样式 [first, ButtonStyle_Danger] 的颜色, 拼接成字符串.
+func themeButtonColorStrings(first int, get func(c ButtonColors) [5]uint32) map[int]string {
+	m := make(map[int]string)
+	for style := first; style <= ButtonStyle_Danger; style++ {
+		colors := get(defaultTheme.ButtonColors[style])
+		m[style] = JoinColorString(colors[:]...)
+	}
+	return m
+}
+
+// setStyle 根据主题和按钮样式选择绘制函数和各个状态下的颜色. 朴素按钮需先设置 plain.
+//
+// t: 主题.
+//
+// style: 按钮样式, 可使用常量: ButtonStyle_.
+func (s *buttonState) setStyle(t *Theme, style int) {
+	colors := t.ButtonColors[style]
+	// 选择不同的绘制事件和颜色
+	var funcDrawEle string
+	var bgColors, textColors, borderColors [5]uint32
+	if style == ButtonStyle_Text { // 无边框无背景
+		funcDrawEle = "onDrawButton_Text"
+		textColors = colors.Text
+	} else {
+		if style == ButtonStyle_Default {
+			funcDrawEle = "onDrawButton_Default"
+		} else if s.plain {
+			funcDrawEle = "onDrawButton_Color_Plain"
+		} else {
+			funcDrawEle = "onDrawButton_Color"
+		}
+		if s.plain {
+			bgColors, textColors, borderColors = colors.PlainBg, colors.PlainText, colors.PlainBorder
+		} else {
+			bgColors, textColors, borderColors = colors.Bg, colors.Text, colors.Border
+		}
+	}
+	s.style = style
+	s.painter = funcDrawEle
+	s.bgColors, s.textColors, s.borderColors = bgColors, textColors, borderColors
+}
+
+// 默认按钮和朴素默认按钮 style 0
+func onDrawButton_Default(ctx *PaintContext) {
+	cv := ctx.Canvas
+	var rc Rect
+	rc.Right = ctx.Width
+	rc.Bottom = ctx.Height
+
+	s, ok := ctx.state.(*buttonState)
+	if !ok {
+		return
+	}
+	nState := ctx.State
+	bgColor := s.bgColors[nState]
+	borderColor := s.borderColors[nState]
+	textColor := s.textColors[nState]
+
+	var rc2 Rect
+	corners := s.corners().squareSides(s.joinSides)
+	if s.circle { // 圆形按钮
+		cv.SetBrushColor(borderColor)
+		cv.DrawEllipse(rc)
+		rc2.Top = 1
+		rc2.Left = 1
+		rc2.Right = rc.Right - 1
+		rc2.Bottom = rc.Bottom - 1
+		cv.SetBrushColor(bgColor)
+		cv.FillEllipse(rc2)
+	} else { // 圆角按钮
+		cv.SetBrushColor(borderColor)
+		cv.DrawRoundRectEx(rc, corners)
+		rc2.Top = 1
+		rc2.Left = 1
+		rc2.Right = rc.Right - 1
+		rc2.Bottom = rc.Bottom - 1
+		cv.SetBrushColor(bgColor)
+		cv.FillRoundRectEx(rc2, corners)
+	}
+	cv.SetBrushColor(textColor)
+
+	drawButtonContent(ctx, s, textColor)
+}
+
+// 彩色按钮 style 1-5
+func onDrawButton_Color(ctx *PaintContext) {
+	cv := ctx.Canvas
+	var rc Rect
+	rc.Right = ctx.Width
+	rc.Bottom = ctx.Height
+
+	s, ok := ctx.state.(*buttonState)
+	if !ok {
+		return
+	}
+	nState := ctx.State
+	bgColor := s.bgColors[nState]
+	textColor := s.textColors[nState]
+
+	if s.circle { // 圆形按钮
+		cv.SetBrushColor(bgColor)
+		cv.FillEllipse(rc)
+	} else { // 圆角按钮
+		cv.SetBrushColor(bgColor)
+		cv.FillRoundRectEx(rc, s.corners().squareSides(s.joinSides))
+	}
+	cv.SetBrushColor(textColor)
+
+	drawButtonContent(ctx, s, textColor)
+}
+
+// 朴素彩色按钮 style 1-5
+func onDrawButton_Color_Plain(ctx *PaintContext) {
+	cv := ctx.Canvas
+	var rc Rect
+	rc.Right = ctx.Width
+	rc.Bottom = ctx.Height
+
+	s, ok := ctx.state.(*buttonState)
+	if !ok {
+		return
+	}
+	nState := ctx.State
+	bgColor := s.bgColors[nState]
+	textColor := s.textColors[nState]
+	borderColor := s.borderColors[nState]
+
+	var rc2 Rect
+	switch nState {
+	case ButtonState_Leave:
+		rc2.Top = 1
+		rc2.Left = 1
+		rc2.Right = rc.Right - 1
+		rc2.Bottom = rc.Bottom - 1
+	case ButtonState_Stay:
+		rc2 = rc
+	case ButtonState_Down:
+		rc2 = rc
+	case ButtonState_Disable:
+		rc2.Top = 1
+		rc2.Left = 1
+		rc2.Right = rc.Right - 1
+		rc2.Bottom = rc.Bottom - 1
+	}
+
+	if s.circle { // 圆形按钮
+		cv.SetBrushColor(borderColor)
+		cv.DrawEllipse(rc)
+		cv.SetBrushColor(bgColor)
+		cv.FillEllipse(rc)
+	} else { // 圆角按钮
+		corners := s.corners().squareSides(s.joinSides)
+		cv.SetBrushColor(borderColor)
+		cv.DrawRoundRectEx(rc, corners)
+		cv.SetBrushColor(bgColor)
+		cv.FillRoundRectEx(rc2, corners)
+	}
+	cv.SetBrushColor(textColor)
+
+	drawButtonContent(ctx, s, textColor)
+}
+
+// 无边框无背景按钮 style 6
+func onDrawButton_Text(ctx *PaintContext) {
+	cv := ctx.Canvas
+	var rc Rect
+	rc.Right = ctx.Width
+	rc.Bottom = ctx.Height
+
+	s, ok := ctx.state.(*buttonState)
+	if !ok {
+		return
+	}
+	nState := ctx.State
+	textColor := s.textColors[nState]
+	cv.SetBrushColor(textColor)
+
+	drawButtonContent(ctx, s, textColor)
+}
+
+// drawButtonContent 绘制按钮的图标和文字, 所有按钮绘制函数共用. 自动换行时文字与左右边框至少间隔 Theme.PaddingButton, 否则文字不限宽度, 超出按钮的部分被裁掉.
+//
+// s: 按钮状态.
+//
+// textColor: 文字颜色, 没有设置图标颜色时也是图标的颜色.
+func drawButtonContent(ctx *PaintContext, s *buttonState, textColor uint32) {
+	gap := s.iconGap
+	if gap < 1 {
+		gap = ctx.Theme.SpaceIconText
+	}
+	rc := Rect{Left: ctx.Theme.PaddingButton, Right: ctx.Width - ctx.Theme.PaddingButton, Bottom: ctx.Height}
+	l := ctx.LayoutContent(rc, ContentOption{IconPosition: s.iconPosition, Gap: gap, Wrap: s.textWrap, Overflow: !s.textWrap})
+	ctx.DrawContent(l, textColor, ctx.IconColor(textColor))
+}
@@ -0,0 +1,93 @@
+//go:build windows
+
+package eui
+
+import (
+	"github.com/twgh/xcgui/widget"
+	"github.com/twgh/xcgui/xc"
+	"github.com/twgh/xcgui/xcc"
+)
+
+// ButtonGroup 是按钮组, 把多个 Button 横排或竖排拼在一起, 相邻按钮共用一条边框, 只有两端的角是圆角. 继承 widget.Element.
+//   - 可用于上一页/下一页, 放大/缩小这样的分段工具栏.
+type ButtonGroup struct {
+	widget.Element
+	buttons  []*Button
+	vertical bool
+}
+
+// CreateButtonGroup 创建按钮组, 按钮会被移动到按钮组中并按顺序排列, 按钮组的大小随按钮自动调整.
+//   - 按钮组创建在 (0, 0) 处, 可使用 SetPosition 移动, 或放在布局元素中.
+//   - 横排时按钮高度统一为最高按钮的高度, 竖排时宽度统一为最宽按钮的宽度.
+//
+// hParent: 父元素或父窗口句柄.
+//
+// buttons: 要放入按钮组的按钮, 可以是不同样式的按钮, 但使用相同样式效果最好.
+func (e *Elementui) CreateButtonGroup(hParent int, buttons ...*Button) *ButtonGroup {
+	g := &ButtonGroup{}
+	g.SetHandle(xc.XEle_Create(0, 0, 0, 0, hParent))
+	// 启用背景透明
+	g.EnableBkTransparent(true)
+	return g.AddButton(buttons...)
+}
+
+// AddButton 在按钮组末尾添加按钮, 然后重新排列.
+//
+// buttons: 要添加的按钮.
+func (g *ButtonGroup) AddButton(buttons ...*Button) *ButtonGroup {
+	for _, btn := range buttons {
+		if btn == nil {
+			continue
+		}
+		g.AddChild(btn.Handle)
+		// 鼠标停留的按钮放到最上面, 这样它的边框不会被相邻按钮盖住
+		btn.Event_MOUSESTAY1(onMouseStayGroupButton)
+		g.buttons = append(g.buttons, btn)
+	}
+	return g.Layout()
+}
+
+// GetButtons 获取按钮组中的按钮.
+func (g *ButtonGroup) GetButtons() []*Button {
+	return g.buttons
+}
+
+// EnableVertical 设置按钮组是否竖排, 然后重新排列.
+//
+// isVertical: 是否竖排, 默认为 false.
+func (g *ButtonGroup) EnableVertical(isVertical bool) *ButtonGroup {
+	g.vertical = isVertical
+	return g.Layout()
+}
+
+// IsVertical 判断按钮组是否竖排.
+func (g *ButtonGroup) IsVertical() bool {
+	return g.vertical
+}
+
+// Layout 重新排列按钮组中的按钮并调整按钮组的大小. 修改了按钮的大小后可调用.
+func (g *ButtonGroup) Layout() *ButtonGroup {
+	sizes := make([]Size, len(g.buttons))
+	for i, btn := range g.buttons {
+		sizes[i] = Size{CX: btn.GetWidth(), CY: btn.GetHeight()}
+	}
+	rects, joinSides, total := layoutButtonGroup(sizes, g.vertical)
+	for i, btn := range g.buttons {
+		rc := xc.RECT(rects[i])
+		btn.SetRect(&rc, false, xcc.AdjustLayout_All, 0)
+		if s := btn.btnState(); s != nil {
+			s.joinSides = joinSides[i]
+		}
+	}
+	g.SetSize(total.CX, total.CY, false, xcc.AdjustLayout_All, 0)
+	g.Redraw(false)
+	return g
+}
+
+// 按钮组中的按钮鼠标停留事件, 把按钮放到最上面.
+func onMouseStayGroupButton(hEle int, pbHandled *bool) int {
+	if hParent := xc.XEle_GetParentEle(hEle); hParent > 0 {
+		xc.XEle_SetZOrder(hEle, xc.XEle_GetChildCount(hParent)-1)
+	}
+	return 0
+}
@@ -0,0 +1,165 @@
+package eui
+
+// Canvas 画布, 绘制函数通过它来绘制, 不直接调用 xc.XDraw_ 系列函数.
+//   - 默认使用 DrawCanvas, 它调用炫彩的 XDraw_ 函数绘制.
+//   - ImageCanvas 是纯 Go 实现的, 绘制到 image.RGBA 上, 不需要炫彩, 可用于测试.
+//   - 颜色值都是 xc.RGBA 的返回值.
+type Canvas interface {
+	// SetBrushColor 设置画刷颜色, 之后的绘制都使用这个颜色.
+	SetBrushColor(color uint32)
+	// DrawRoundRect 绘制圆角矩形边框, 圆角小于 1 时为直角.
+	DrawRoundRect(rc Rect, round int32)
+	// FillRoundRect 填充圆角矩形, 圆角小于 1 时为直角.
+	FillRoundRect(rc Rect, round int32)
+	// DrawRoundRectEx 绘制四个角圆角大小不同的圆角矩形边框, 圆角小于 1 的角为直角.
+	DrawRoundRectEx(rc Rect, corners Corners)
+	// FillRoundRectEx 填充四个角圆角大小不同的圆角矩形, 圆角小于 1 的角为直角.
+	FillRoundRectEx(rc Rect, corners Corners)
+	// DrawEllipse 绘制椭圆边框.
+	DrawEllipse(rc Rect)
+	// FillEllipse 填充椭圆.
+	FillEllipse(rc Rect)
+
+	// SetFont 设置字体, 之后的文本绘制都使用这个字体.
+	//  - hFont: 炫彩字体句柄, 为 0 时使用默认字体.
+	SetFont(hFont int)
+	// SetTextAlign 设置文本对齐方式, 可使用常量: TextAlign_, 可组合使用.
+	SetTextAlign(align int)
+	// DrawText 在矩形内绘制文本.
+	DrawText(text string, rc Rect)
+	// TextSize 获取文本使用指定字体显示时的大小.
+	//  - hFont: 炫彩字体句柄, 为 0 时使用默认字体.
+	TextSize(text string, hFont int) Size
+
+	// DrawSvg 绘制炫彩 svg, 并把 svg 的填充颜色设置为 color.
+	DrawSvg(hSvg int, x, y int32, color uint32)
+	// SvgSize 获取炫彩 svg 的大小, 不是有效的 svg 句柄时 ok 返回 false.
+	SvgSize(hSvg int) (size Size, ok bool)
+	// DrawImage 绘制炫彩图片.
+	DrawImage(hImage int, x, y int32)
+	// ImageSize 获取炫彩图片的大小, 不是有效的图片句柄时 ok 返回 false.
+	ImageSize(hImage int) (size Size, ok bool)
+}
+
+// Rect 矩形, 与 xc.RECT 相同.
+type Rect struct {
+	Left   int32
+	Top    int32
+	Right  int32
+	Bottom int32
+}
+
+// Width 返回矩形宽度.
+func (rc Rect) Width() int32 {
+	return rc.Right - rc.Left
+}
+
+// Height 返回矩形高度.
+func (rc Rect) Height() int32 {
+	return rc.Bottom - rc.Top
+}
+
+// contains 判断点是否在矩形内, 不包括右边和底边.
+func (rc Rect) contains(x, y int32) bool {
+	return x >= rc.Left && x < rc.Right && y >= rc.Top && y < rc.Bottom
+}
+
+// 矩形的边, 可组合使用.
+
+const (
+	side_Left   = 1 << iota // 左边
+	side_Top                // 上边
+	side_Right              // 右边
+	side_Bottom             // 下边
+)
+
+// Corners 圆角矩形四个角的圆角大小, 顺序与 xc.XDraw_DrawRoundRectEx 的参数相同.
+type Corners struct {
+	LeftTop     int32 // 左上角
+	RightTop    int32 // 右上角
+	RightBottom int32 // 右下角
+	LeftBottom  int32 // 左下角
+}
+
+// uniformCorners 返回四个角圆角大小都是 round 的 Corners.
+func uniformCorners(round int32) Corners {
+	return Corners{LeftTop: round, RightTop: round, RightBottom: round, LeftBottom: round}
+}
+
+// isUniform 判断四个角的圆角大小是否相同.
+func (c Corners) isUniform() bool {
+	return c.LeftTop == c.RightTop && c.LeftTop == c.RightBottom && c.LeftTop == c.LeftBottom
+}
+
+// max 返回四个角中最大的圆角大小.
+func (c Corners) max() int32 {
+	m := c.LeftTop
+	for _, v := range []int32{c.RightTop, c.RightBottom, c.LeftBottom} {
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
+
+// squareSides 把指定边上的两个角变成直角, 用于按钮组中与相邻按钮相接的边.
+//
+// sides: 边, 是 side_ 常量的组合.
+func (c Corners) squareSides(sides int) Corners {
+	if sides&(side_Left|side_Top) != 0 {
+		c.LeftTop = 0
+	}
+	if sides&(side_Top|side_Right) != 0 {
+		c.RightTop = 0
+	}
+	if sides&(side_Right|side_Bottom) != 0 {
+		c.RightBottom = 0
+	}
+	if sides&(side_Bottom|side_Left) != 0 {
+		c.LeftBottom = 0
+	}
+	return c
+}
+
+// scale 把每个角的圆角大小乘以 mul 再除以 div, 用于按 dpi 缩放. 小于 0 的值当作 0.
+func (c Corners) scale(mul, div int32) Corners {
+	for _, v := range []*int32{&c.LeftTop, &c.RightTop, &c.RightBottom, &c.LeftBottom} {
+		if *v < 0 {
+			*v = 0
+		}
+		*v = *v * mul / div
+	}
+	return c
+}
+
+// Size 大小, 与 xc.SIZE 相同.
+type Size struct {
+	CX int32
+	CY int32
+}
+
+// 文本对齐方式, 可组合使用.
+
+const (
+	TextAlign_Left    = 0      // 左对齐
+	TextAlign_Top     = 0      // 顶对齐
+	TextAlign_Center  = 1 << 0 // 水平居中
+	TextAlign_Right   = 1 << 1 // 右对齐
+	TextAlign_VCenter = 1 << 2 // 垂直居中
+	TextAlign_Bottom  = 1 << 3 // 底对齐
+	TextAlign_NoWrap  = 1 << 4 // 不换行
+)
+
+// offsetRect 与 xc.OffsetRect 相同, 矩形的四个边分别加上对应的值.
+func offsetRect(rc Rect, left, top, right, bottom int32) Rect {
+	rc.Left += left
+	rc.Top += top
+	rc.Right += right
+	rc.Bottom += bottom
+	return rc
+}
+
+// rgba 与 xc.RGBA 相同, 把 r, g, b, a 组合成颜色值.
+func rgba(r, g, b, a byte) uint32 {
+	return uint32(r) | uint32(g)<<8 | uint32(b)<<16 | uint32(a)<<24
+}
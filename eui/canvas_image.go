@@ -0,0 +1,333 @@
+package eui
+
+import (
+	"image"
+	"image/draw"
+	"math"
+)
+
+// ImageCanvas 是绘制到 image.RGBA 上的 Canvas, 纯 Go 实现, 不需要炫彩, 可以在任何系统上测试绘制函数.
+//   - 没有使用真正的字体, 文本的每个字符画成一个方块, 大小由字号决定, 这样绘制结果在任何机器上都相同.
+//   - 炫彩 svg 需先用 AddSvg 登记大小, 绘制时画成一个填充了 svg 颜色的矩形.
+//   - 炫彩图片需先用 AddImage 登记对应的 image.Image, 绘制时直接画这张图片.
+//   - 字体需先用 AddFont 登记字号, 没有登记的字体使用 DefaultFontSize.
+type ImageCanvas struct {
+	// 默认字体的字号, 单位是像素, 默认 12.
+	DefaultFontSize int32
+
+	img    *image.RGBA
+	brush  uint32
+	hFont  int
+	align  int
+	fonts  map[int]int32
+	svgs   map[int]Size
+	images map[int]image.Image
+}
+
+// NewImageCanvas 创建图片画布, 图片初始是全透明的.
+//
+// width, height: 图片宽高.
+func NewImageCanvas(width, height int32) *ImageCanvas {
+	return &ImageCanvas{
+		DefaultFontSize: 12,
+		img:             image.NewRGBA(image.Rect(0, 0, int(width), int(height))),
+		fonts:           make(map[int]int32),
+		svgs:            make(map[int]Size),
+		images:          make(map[int]image.Image),
+	}
+}
+
+// Image 返回绘制结果.
+func (c *ImageCanvas) Image() *image.RGBA {
+	return c.img
+}
+
+// AddFont 登记字体的字号.
+//
+// hFont: 炫彩字体句柄, 可以是任意不为 0 的数字.
+//
+// size: 字号, 单位是像素.
+func (c *ImageCanvas) AddFont(hFont int, size int32) *ImageCanvas {
+	c.fonts[hFont] = size
+	return c
+}
+
+// AddSvg 登记炫彩 svg 的大小.
+//
+// hSvg: 炫彩 svg 句柄, 可以是任意不为 0 的数字.
+//
+// width, height: svg 宽高.
+func (c *ImageCanvas) AddSvg(hSvg int, width, height int32) *ImageCanvas {
+	c.svgs[hSvg] = Size{CX: width, CY: height}
+	return c
+}
+
+// AddImage 登记炫彩图片对应的图片.
+//
+// hImage: 炫彩图片句柄, 可以是任意不为 0 的数字.
+//
+// img: 绘制时画的图片.
+func (c *ImageCanvas) AddImage(hImage int, img image.Image) *ImageCanvas {
+	c.images[hImage] = img
+	return c
+}
+
+// SetBrushColor 设置画刷颜色.
+func (c *ImageCanvas) SetBrushColor(color uint32) {
+	c.brush = color
+}
+
+// DrawRoundRect 绘制 1 像素宽的圆角矩形边框, 边框画在矩形内侧.
+func (c *ImageCanvas) DrawRoundRect(rc Rect, round int32) {
+	c.DrawRoundRectEx(rc, uniformCorners(round))
+}
+
+// FillRoundRect 填充圆角矩形.
+func (c *ImageCanvas) FillRoundRect(rc Rect, round int32) {
+	c.FillRoundRectEx(rc, uniformCorners(round))
+}
+
+// DrawRoundRectEx 绘制 1 像素宽, 四个角圆角大小不同的圆角矩形边框, 边框画在矩形内侧.
+func (c *ImageCanvas) DrawRoundRectEx(rc Rect, corners Corners) {
+	inner := offsetRect(rc, 1, 1, -1, -1)
+	innerCorners := Corners{corners.LeftTop - 1, corners.RightTop - 1, corners.RightBottom - 1, corners.LeftBottom - 1}
+	c.fill(rc, func(x, y float64) bool {
+		return inRoundRect(rc, corners, x, y) && !inRoundRect(inner, innerCorners, x, y)
+	})
+}
+
+// FillRoundRectEx 填充四个角圆角大小不同的圆角矩形.
+func (c *ImageCanvas) FillRoundRectEx(rc Rect, corners Corners) {
+	c.fill(rc, func(x, y float64) bool {
+		return inRoundRect(rc, corners, x, y)
+	})
+}
+
+// DrawEllipse 绘制 1 像素宽的椭圆边框, 边框画在矩形内侧.
+func (c *ImageCanvas) DrawEllipse(rc Rect) {
+	inner := offsetRect(rc, 1, 1, -1, -1)
+	c.fill(rc, func(x, y float64) bool {
+		return inEllipse(rc, x, y) && !inEllipse(inner, x, y)
+	})
+}
+
+// FillEllipse 填充椭圆.
+func (c *ImageCanvas) FillEllipse(rc Rect) {
+	c.fill(rc, func(x, y float64) bool {
+		return inEllipse(rc, x, y)
+	})
+}
+
+// SetFont 设置字体, 为 0 时使用默认字体.
+func (c *ImageCanvas) SetFont(hFont int) {
+	c.hFont = hFont
+}
+
+// SetTextAlign 设置文本对齐方式, 可使用常量: TextAlign_. 文本总是不换行的.
+func (c *ImageCanvas) SetTextAlign(align int) {
+	c.align = align
+}
+
+// DrawText 在矩形内绘制文本, 每个字符画成一个方块, 空白字符不画, 超出矩形的部分会被裁剪.
+func (c *ImageCanvas) DrawText(text string, rc Rect) {
+	fontSize := c.fontSize(c.hFont)
+	size := c.TextSize(text, c.hFont)
+	x := rc.Left
+	if c.align&TextAlign_Center != 0 {
+		x += (rc.Width() - size.CX) / 2
+	} else if c.align&TextAlign_Right != 0 {
+		x = rc.Right - size.CX
+	}
+	y := rc.Top
+	if c.align&TextAlign_VCenter != 0 {
+		y += (rc.Height() - size.CY) / 2
+	} else if c.align&TextAlign_Bottom != 0 {
+		y = rc.Bottom - size.CY
+	}
+
+	// 方块高度是字号的 2/3, 在行内垂直居中
+	boxHeight := fontSize * 2 / 3
+	boxTop := y + (size.CY-boxHeight)/2
+	for _, r := range text {
+		w := glyphWidth(r, fontSize)
+		if r > ' ' {
+			box := Rect{Left: x + 1, Top: boxTop, Right: x + w - 1, Bottom: boxTop + boxHeight}
+			box = intersectRect(box, rc)
+			c.fill(box, func(float64, float64) bool { return true })
+		}
+		x += w
+	}
+}
+
+// TextSize 获取文本显示时的大小, 宽度是每个字符宽度之和, 高度是字号的 4/3.
+func (c *ImageCanvas) TextSize(text string, hFont int) Size {
+	fontSize := c.fontSize(hFont)
+	var size Size
+	for _, r := range text {
+		size.CX += glyphWidth(r, fontSize)
+	}
+	size.CY = fontSize * 4 / 3
+	return size
+}
+
+// DrawSvg 把 svg 画成一个 color 颜色的矩形.
+func (c *ImageCanvas) DrawSvg(hSvg int, x, y int32, color uint32) {
+	size, ok := c.svgs[hSvg]
+	if !ok {
+		return
+	}
+	brush := c.brush
+	c.brush = color
+	c.fill(Rect{Left: x, Top: y, Right: x + size.CX, Bottom: y + size.CY}, func(float64, float64) bool { return true })
+	c.brush = brush
+}
+
+// SvgSize 获取已登记的 svg 大小, 没有登记时 ok 返回 false.
+func (c *ImageCanvas) SvgSize(hSvg int) (Size, bool) {
+	size, ok := c.svgs[hSvg]
+	return size, ok
+}
+
+// DrawImage 绘制已登记的图片.
+func (c *ImageCanvas) DrawImage(hImage int, x, y int32) {
+	img, ok := c.images[hImage]
+	if !ok {
+		return
+	}
+	b := img.Bounds()
+	dst := image.Rect(int(x), int(y), int(x)+b.Dx(), int(y)+b.Dy())
+	draw.Draw(c.img, dst, img, b.Min, draw.Over)
+}
+
+// ImageSize 获取已登记的图片大小, 没有登记时 ok 返回 false.
+func (c *ImageCanvas) ImageSize(hImage int) (Size, bool) {
+	img, ok := c.images[hImage]
+	if !ok {
+		return Size{}, false
+	}
+	b := img.Bounds()
+	return Size{CX: int32(b.Dx()), CY: int32(b.Dy())}, true
+}
+
+// fontSize 返回字体的字号.
+func (c *ImageCanvas) fontSize(hFont int) int32 {
+	if size, ok := c.fonts[hFont]; ok {
+		return size
+	}
+	return c.DefaultFontSize
+}
+
+// samples 是每个像素在每个方向上的采样数, 用于抗锯齿.
+const samples = 4
+
+// fill 用画刷颜色填充矩形内 inside 返回 true 的部分, 边缘按采样覆盖率混合.
+//
+// rc: 要填充的范围.
+//
+// inside: 判断一个点是否需要填充.
+func (c *ImageCanvas) fill(rc Rect, inside func(x, y float64) bool) {
+	rc = intersectRect(rc, Rect{Right: int32(c.img.Rect.Dx()), Bottom: int32(c.img.Rect.Dy())})
+	for py := rc.Top; py < rc.Bottom; py++ {
+		for px := rc.Left; px < rc.Right; px++ {
+			n := 0
+			for sy := 0; sy < samples; sy++ {
+				for sx := 0; sx < samples; sx++ {
+					if inside(float64(px)+(float64(sx)+0.5)/samples, float64(py)+(float64(sy)+0.5)/samples) {
+						n++
+					}
+				}
+			}
+			if n > 0 {
+				c.blend(int(px), int(py), float64(n)/(samples*samples))
+			}
+		}
+	}
+}
+
+// blend 把画刷颜色按覆盖率混合到像素上.
+func (c *ImageCanvas) blend(x, y int, coverage float64) {
+	a := float64(c.brush>>24&0xFF) / 255 * coverage
+	if a <= 0 {
+		return
+	}
+	i := c.img.PixOffset(x, y)
+	pix := c.img.Pix[i : i+4 : i+4]
+	for k := 0; k < 3; k++ {
+		src := float64(c.brush >> (8 * k) & 0xFF)
+		pix[k] = uint8(math.Round(src*a + float64(pix[k])*(1-a)))
+	}
+	pix[3] = uint8(math.Round(255*a + float64(pix[3])*(1-a)))
+}
+
+// glyphWidth 返回字符的方块宽度, 全角字符, 表情和图标字体的私有区字符与字号同宽, 附加在前一个字符上的字符宽度为 0, 其它字符是字号的一半.
+func glyphWidth(r rune, fontSize int32) int32 {
+	if isExtendRune(r) {
+		return 0
+	}
+	if r >= 0x1100 {
+		return fontSize
+	}
+	return (fontSize + 1) / 2
+}
+
+// inRoundRect 判断点是否在圆角矩形内, 每个角的圆角最大为宽高较小值的一半.
+func inRoundRect(rc Rect, corners Corners, x, y float64) bool {
+	left, top, right, bottom := float64(rc.Left), float64(rc.Top), float64(rc.Right), float64(rc.Bottom)
+	if x < left || x > right || y < top || y > bottom {
+		return false
+	}
+	// 点所在那一半的角
+	midX, midY := (left+right)/2, (top+bottom)/2
+	var round int32
+	switch {
+	case x < midX && y < midY:
+		round = corners.LeftTop
+	case x >= midX && y < midY:
+		round = corners.RightTop
+	case x >= midX:
+		round = corners.RightBottom
+	default:
+		round = corners.LeftBottom
+	}
+	r := math.Min(float64(round), math.Min(right-left, bottom-top)/2)
+	if r <= 0 {
+		return true
+	}
+	// 找到最近的圆角圆心, 点在圆角区域时判断与圆心的距离
+	cx := math.Max(left+r, math.Min(x, right-r))
+	cy := math.Max(top+r, math.Min(y, bottom-r))
+	dx, dy := x-cx, y-cy
+	return dx*dx+dy*dy <= r*r
+}
+
+// inEllipse 判断点是否在矩形的内切椭圆内.
+func inEllipse(rc Rect, x, y float64) bool {
+	a := float64(rc.Width()) / 2
+	b := float64(rc.Height()) / 2
+	if a <= 0 || b <= 0 {
+		return false
+	}
+	dx := (x - float64(rc.Left) - a) / a
+	dy := (y - float64(rc.Top) - b) / b
+	return dx*dx+dy*dy <= 1
+}
+
+// intersectRect 返回两个矩形的交集, 没有交集时返回空矩形.
+func intersectRect(a, b Rect) Rect {
+	if a.Left < b.Left {
+		a.Left = b.Left
+	}
+	if a.Top < b.Top {
+		a.Top = b.Top
+	}
+	if a.Right > b.Right {
+		a.Right = b.Right
+	}
+	if a.Bottom > b.Bottom {
+		a.Bottom = b.Bottom
+	}
+	if a.Right < a.Left || a.Bottom < a.Top {
+		return Rect{}
+	}
+	return a
+}
@@ -0,0 +1,93 @@
+package eui
+
+import (
+	"image/color"
+	"testing"
+)
+
+func Test_ImageCanvas(t *testing.T) {
+	c := NewImageCanvas(40, 20)
+	c.SetBrushColor(rgba(64, 158, 255, 255))
+	c.FillRoundRect(Rect{Right: 40, Bottom: 20}, 8)
+
+	img := c.Image()
+	if got := img.RGBAAt(20, 10); got != (color.RGBA{R: 64, G: 158, B: 255, A: 255}) {
+		t.Errorf("center = %v", got)
+	}
+	// 圆角外面是透明的
+	if got := img.RGBAAt(0, 0); got.A != 0 {
+		t.Errorf("corner = %v, want transparent", got)
+	}
+	// 圆角边缘是半透明的
+	if got := img.RGBAAt(1, 3); got.A == 0 || got.A == 255 {
+		t.Errorf("edge = %v, want partially covered", got)
+	}
+
+	size := c.TextSize("ab中", 0)
+	if size.CX != 6+6+12 || size.CY != 16 {
+		t.Errorf("TextSize = %+v", size)
+	}
+}
+
+func Test_ImageCanvas_FillRoundRectEx(t *testing.T) {
+	c := NewImageCanvas(40, 20)
+	c.SetBrushColor(rgba(64, 158, 255, 255))
+	c.FillRoundRectEx(Rect{Right: 40, Bottom: 20}, Corners{LeftTop: 8, RightBottom: 8})
+
+	img := c.Image()
+	// 有圆角的角外面是透明的, 直角的角是填满的
+	corners := []struct {
+		x, y  int
+		round bool
+	}{{0, 0, true}, {39, 0, false}, {39, 19, true}, {0, 19, false}}
+	for _, p := range corners {
+		got := img.RGBAAt(p.x, p.y)
+		if p.round && got.A != 0 || !p.round && got.A != 255 {
+			t.Errorf("(%d, %d) = %v, want round %v", p.x, p.y, got, p.round)
+		}
+	}
+}
+
+func Test_Corners_squareSides(t *testing.T) {
+	c := uniformCorners(4)
+	if got, want := c.squareSides(side_Right), (Corners{LeftTop: 4, LeftBottom: 4}); got != want {
+		t.Errorf("right = %+v, want %+v", got, want)
+	}
+	if got, want := c.squareSides(side_Top|side_Bottom), (Corners{}); got != want {
+		t.Errorf("top and bottom = %+v, want %+v", got, want)
+	}
+	if got := c.squareSides(0); got != c {
+		t.Errorf("none = %+v, want %+v", got, c)
+	}
+}
+
+func Test_onDrawButton_Color(t *testing.T) {
+	s := &buttonState{}
+	s.round = 4
+	s.setStyle(defaultTheme, ButtonStyle_Primary)
+
+	c := NewImageCanvas(98, 40)
+	ctx := &PaintContext{
+		Canvas:  c,
+		Width:   98,
+		Height:  40,
+		Theme:   defaultTheme,
+		Text:    "OK",
+		State:   ButtonState_Leave,
+		Enable:  true,
+		Handled: true,
+		state:   s,
+	}
+	painterMap[s.painter](ctx)
+
+	img := c.Image()
+	want := color.RGBA{R: 64, G: 158, B: 255, A: 255}
+	if got := img.RGBAAt(10, 20); got != want {
+		t.Errorf("background = %v, want %v", got, want)
+	}
+	// 文字在中间, 画成白色方块
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	if got := img.RGBAAt(45, 20); got != white {
+		t.Errorf("text = %v, want %v", got, white)
+	}
+}
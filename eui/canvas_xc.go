@@ -0,0 +1,144 @@
+//go:build windows
+
+package eui
+
+import (
+	"github.com/twgh/xcgui/xc"
+	"github.com/twgh/xcgui/xcc"
+)
+
+// DrawCanvas 是调用炫彩 XDraw_ 函数绘制的 Canvas, 元素绘制时默认使用它.
+type DrawCanvas struct {
+	HDraw int // 炫彩图形句柄
+}
+
+// NewDrawCanvas 创建炫彩图形画布, 会启用平滑模式.
+//   - 可在自己的元素绘制事件中使用, 这样就能调用 Elementui 的绘制函数来绘制.
+//
+// hDraw: 炫彩图形句柄.
+func NewDrawCanvas(hDraw int) *DrawCanvas {
+	xc.XDraw_EnableSmoothingMode(hDraw, true)
+	return &DrawCanvas{HDraw: hDraw}
+}
+
+// SetBrushColor 设置画刷颜色.
+func (c *DrawCanvas) SetBrushColor(color uint32) {
+	xc.XDraw_SetBrushColor(c.HDraw, color)
+}
+
+// DrawRoundRect 绘制圆角矩形边框.
+func (c *DrawCanvas) DrawRoundRect(rc Rect, round int32) {
+	xrc := xc.RECT(rc)
+	xc.XDraw_DrawRoundRect(c.HDraw, &xrc, round, round)
+}
+
+// FillRoundRect 填充圆角矩形.
+func (c *DrawCanvas) FillRoundRect(rc Rect, round int32) {
+	xrc := xc.RECT(rc)
+	xc.XDraw_FillRoundRect(c.HDraw, &xrc, round, round)
+}
+
+// DrawRoundRectEx 绘制四个角圆角大小不同的圆角矩形边框. 四个角相同时与 DrawRoundRect 一样.
+func (c *DrawCanvas) DrawRoundRectEx(rc Rect, corners Corners) {
+	if corners.isUniform() {
+		c.DrawRoundRect(rc, corners.LeftTop)
+		return
+	}
+	xrc := xc.RECT(rc)
+	xc.XDraw_DrawRoundRectEx(c.HDraw, &xrc, corners.LeftTop, corners.RightTop, corners.RightBottom, corners.LeftBottom)
+}
+
+// FillRoundRectEx 填充四个角圆角大小不同的圆角矩形. 四个角相同时与 FillRoundRect 一样.
+func (c *DrawCanvas) FillRoundRectEx(rc Rect, corners Corners) {
+	if corners.isUniform() {
+		c.FillRoundRect(rc, corners.LeftTop)
+		return
+	}
+	xrc := xc.RECT(rc)
+	xc.XDraw_FillRoundRectEx(c.HDraw, &xrc, corners.LeftTop, corners.RightTop, corners.RightBottom, corners.LeftBottom)
+}
+
+// DrawEllipse 绘制椭圆边框.
+func (c *DrawCanvas) DrawEllipse(rc Rect) {
+	xrc := xc.RECT(rc)
+	xc.XDraw_DrawEllipse(c.HDraw, &xrc)
+}
+
+// FillEllipse 填充椭圆.
+func (c *DrawCanvas) FillEllipse(rc Rect) {
+	xrc := xc.RECT(rc)
+	xc.XDraw_FillEllipse(c.HDraw, &xrc)
+}
+
+// SetFont 设置字体, 为 0 时使用默认字体.
+func (c *DrawCanvas) SetFont(hFont int) {
+	if hFont == 0 {
+		hFont = xc.XC_GetDefaultFont()
+	}
+	xc.XDraw_SetFont(c.HDraw, hFont)
+}
+
+// SetTextAlign 设置文本对齐方式, 可使用常量: TextAlign_.
+func (c *DrawCanvas) SetTextAlign(align int) {
+	var flag xcc.TextFormatFlag_
+	if align&TextAlign_Center != 0 {
+		flag |= xcc.TextAlignFlag_Center
+	}
+	if align&TextAlign_Right != 0 {
+		flag |= xcc.TextAlignFlag_Right
+	}
+	if align&TextAlign_VCenter != 0 {
+		flag |= xcc.TextAlignFlag_Vcenter
+	}
+	if align&TextAlign_Bottom != 0 {
+		flag |= xcc.TextAlignFlag_Bottom
+	}
+	if align&TextAlign_NoWrap != 0 {
+		flag |= xcc.TextFormatFlag_NoWrap
+	}
+	xc.XDraw_SetTextAlign(c.HDraw, flag)
+}
+
+// DrawText 在矩形内绘制文本.
+func (c *DrawCanvas) DrawText(text string, rc Rect) {
+	xrc := xc.RECT(rc)
+	xc.XDraw_DrawText(c.HDraw, text, &xrc)
+}
+
+// TextSize 获取文本使用指定字体显示时的大小, hFont 为 0 时使用默认字体.
+//   - 炫彩需要的文本长度是 UTF-16 长度, 中文和 emoji 按字节数传入会测量错误.
+func (c *DrawCanvas) TextSize(text string, hFont int) Size {
+	if hFont == 0 {
+		hFont = xc.XC_GetDefaultFont()
+	}
+	var size xc.SIZE
+	xc.XC_GetTextShowSize(text, utf16Len(text), hFont, &size)
+	return Size(size)
+}
+
+// DrawSvg 绘制炫彩 svg, 并把 svg 的填充颜色设置为 color.
+func (c *DrawCanvas) DrawSvg(hSvg int, x, y int32, color uint32) {
+	xc.XSvg_SetUserFillColor(hSvg, color, true)
+	xc.XDraw_DrawSvg(c.HDraw, hSvg, x, y)
+}
+
+// SvgSize 获取炫彩 svg 的大小.
+func (c *DrawCanvas) SvgSize(hSvg int) (Size, bool) {
+	if !xc.XC_IsHXCGUI(hSvg, xcc.XC_SVG) {
+		return Size{}, false
+	}
+	return Size{CX: xc.XSvg_GetWidth(hSvg), CY: xc.XSvg_GetHeight(hSvg)}, true
+}
+
+// DrawImage 绘制炫彩图片.
+func (c *DrawCanvas) DrawImage(hImage int, x, y int32) {
+	xc.XDraw_Image(c.HDraw, hImage, x, y)
+}
+
+// ImageSize 获取炫彩图片的大小.
+func (c *DrawCanvas) ImageSize(hImage int) (Size, bool) {
+	if !xc.XC_IsHXCGUI(hImage, xcc.XC_IMAGE_FRAME) {
+		return Size{}, false
+	}
+	return Size{CX: xc.XImage_GetWidth(hImage), CY: xc.XImage_GetHeight(hImage)}, true
+}
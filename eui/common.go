@@ -3,8 +3,7 @@ package eui
 import (
 	"strconv"
 	"strings"
-
-	"github.com/twgh/xcgui/common"
+	"unicode"
 )
 
 // Xchar 传入 Unicode 码点转换到字符. 如 20013 是'中'.
@@ -25,11 +24,43 @@ func JoinColorString(colors ...uint32) string {
 		if i > 0 {
 			sb.WriteString(",")
 		}
-		sb.WriteString(common.Uint32ToA(color))
+		sb.WriteString(strconv.FormatUint(uint64(color), 10))
 	}
 	return sb.String()
 }
 
+// utf16Len 返回文本转换成 UTF-16 后的长度, 炫彩函数的文本长度参数都是这个长度, 不是 UTF-8 的字节数.
+//   - 码点大于 0xFFFF 的字符, 如大部分 emoji, 占两个长度.
+func utf16Len(text string) int32 {
+	var n int32
+	for _, r := range text {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// isExtendRune 判断字符是否附加在前一个字符上显示, 如组合附加符号, 变体选择符, emoji 肤色和零宽连接符. 截断和换行时不能把它和前一个字符分开.
+func isExtendRune(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me) ||
+		r == 0x200D || // 零宽连接符
+		r >= 0xFE00 && r <= 0xFE0F || // 变体选择符
+		r >= 0x1F3FB && r <= 0x1F3FF // emoji 肤色
+}
+
+// clusterStart 返回 runes[:i] 之后能断开的位置, 不会把附加字符和前一个字符分开, 也不会断在零宽连接符后面. 最小返回 0.
+//
+// i: 想要断开的位置.
+func clusterStart(runes []rune, i int) int {
+	for i > 0 && i < len(runes) && (isExtendRune(runes[i]) || runes[i-1] == 0x200D) {
+		i--
+	}
+	return i
+}
+
 const (
 	// 加载
 	svg_loading = `<svg t="1731132887070" class="icon" viewBox="0 0 1024 1024" version="1.1" xmlns="http://www.w3.org/2000/svg" p-id="4306" width="16" height="16"><path d="M512 97c-11.4 0-20.8 9.3-20.8 20.8v166c0 11.4 9.3 20.8 20.8 20.8s20.8-9.3 20.8-20.8v-166c0-11.5-9.4-20.8-20.8-20.8zM247.9 218.6c-8.1-8.1-21.3-8.1-29.3 0s-8.1 21.3 0 29.3L336 365.3c8.1 8.1 21.3 8.1 29.3 0s8.1-21.3 0-29.3L247.9 218.6zM304.5 512c0-11.4-9.3-20.8-20.8-20.8h-166c-11.4 0-20.8 9.3-20.8 20.8s9.3 20.8 20.8 20.8h166c11.5 0 20.8-9.4 20.8-20.8zM335.9 658.7L218.6 776.1c-8.1 8.1-8.1 21.3 0 29.3 8.1 8.1 21.3 8.1 29.3 0L365.3 688c8.1-8.1 8.1-21.3 0-29.3s-21.3-8-29.4 0zM512 719.5c-11.4 0-20.8 9.3-20.8 20.8v166c0 11.4 9.3 20.8 20.8 20.8s20.8-9.3 20.8-20.8v-166c0-11.5-9.4-20.8-20.8-20.8zM688.1 658.7c-8.1-8.1-21.3-8.1-29.3 0s-8.1 21.3 0 29.3l117.4 117.4c8.1 8.1 21.3 8.1 29.3 0 8.1-8.1 8.1-21.3 0-29.3L688.1 658.7zM906.3 491.3h-166c-11.4 0-20.8 9.3-20.8 20.8s9.3 20.8 20.8 20.8h166c11.4 0 20.8-9.3 20.8-20.8s-9.4-20.8-20.8-20.8zM688.1 365.3l117.4-117.4c8.1-8.1 8.1-21.3 0-29.3s-21.3-8.1-29.3 0L658.7 335.9c-8.1 8.1-8.1 21.3 0 29.3s21.3 8.1 29.4 0.1z" p-id="4307"></path></svg>`
@@ -1,2 +1,5 @@
-// Package eui 封装了 Elementui, Button, Edit.
+// Package eui 封装了 Elementui, Button, Edit, Element.
+//
+// 依赖炫彩的文件只在 windows 下编译. 主题, 状态和绘制函数不依赖炫彩, 绘制函数通过 Canvas 绘制,
+// 可以用 ImageCanvas 在任何系统上测试.
 package eui
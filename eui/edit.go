@@ -1,9 +1,8 @@
+//go:build windows
+
 package eui
 
 import (
-	"strconv"
-
-	"github.com/twgh/xcgui/common"
 	"github.com/twgh/xcgui/widget"
 	"github.com/twgh/xcgui/xc"
 	"github.com/twgh/xcgui/xcc"
@@ -17,10 +16,13 @@ type Edit struct {
 
 // CreateEdit 创建编辑框.
 //   - 内部设置了边框大小, 这个影响的是文本的位置. 如果觉得不合适可自行调用 SetBorderSize 进行设置.
-//   - 无图标时左右边框大小都是 15.
-//   - 左边图标时, 左边框大小是 29, 右边框大小是 15.
+//   - 无图标时左右边框大小都是 15 (Theme.PaddingInput).
+//   - 左边图标时, 左边框大小是 29 (Theme.PaddingInputIcon), 右边框大小是 15.
 //   - 右边图标时, 左边框大小是 15, 右边框大小是 29.
-//   - 内部注册了元素绘制事件, 鼠标进入/离开事件, 编辑框光标位置改变事件.
+//   - 设置了 IconSize 的大图标, 图标那边的边框大小会按图标宽度加大.
+//   - 可清空或可切换显示密码时, 右边框大小会加上这些图标的宽度.
+//   - 有前置或后置内容时, 那边的边框大小会加上它们的宽度.
+//   - 内部注册了元素绘制事件, 鼠标进入/离开/移动事件, 鼠标左键按下事件, 编辑框光标位置改变事件, 元素大小改变事件, 元素销毁事件.
 //
 // hParent: 父元素或父窗口句柄.
 //
@@ -69,6 +71,7 @@ func updateEdit(e *Elementui, isChange bool, hParent, hEdit int, opts ...EditOpt
 	}
 	edit.SetHandle(hEdit)
 	edit.H = edit.Handle
+	e.addEle(&edit.objBase, &editState{})
 	if isChange {
 		// 正确填写 Size 时才改变宽高
 		edit.SetSizeEle(opt.Size)
@@ -79,10 +82,13 @@ func updateEdit(e *Elementui, isChange bool, hParent, hEdit int, opts ...EditOpt
 		}
 	}
 
+	theme := e.theme
 	// 设置圆角大小
-	edit.SetRound(4)
+	edit.SetRound(theme.BorderRadiusBase)
 	// 左边填充
-	edit.SetProperty("element-space-left", xc.Itoa(4*e.dpi/96))
+	if s := edit.edState(); s != nil {
+		s.spaceLeft = theme.SpaceInputIcon * e.dpi / 96
+	}
 
 	// 启用背景透明
 	edit.EnableBkTransparent(true)
@@ -91,13 +97,14 @@ func updateEdit(e *Elementui, isChange bool, hParent, hEdit int, opts ...EditOpt
 		edit.SetDefaultText(opt.DefaultText)
 	}
 	// 置默认文本颜色
-	edit.SetDefaultTextColor(xc.RGBA(193, 196, 203, 255))
+	edit.SetDefaultTextColor(theme.ColorTextPlaceholder)
 	// 置插入符颜色
-	edit.SetCaretColor(xc.RGBA(96, 98, 102, 255))
+	edit.SetCaretColor(theme.ColorTextRegular)
 	// 置文本颜色
-	edit.SetTextColor(xc.RGBA(96, 98, 102, 255))
+	edit.SetTextColor(theme.ColorTextRegular)
 
-	hasIcon := true // 是否有图标
+	// 图标大小和颜色要在设置图标前设置, 这样字体只需要确定一次
+	edit.SetIconSize(opt.IconSize).SetIconColor(opt.IconColor)
 	// 自定义炫彩 svg 句柄优先级最高, 其次是炫彩图片句柄, 再然后是 iconFa
 	if opt.HSvg > 0 && xc.XC_IsHXCGUI(opt.HSvg, xcc.XC_SVG) {
 		edit.SetHSvg(opt.HSvg)
@@ -105,38 +112,44 @@ func updateEdit(e *Elementui, isChange bool, hParent, hEdit int, opts ...EditOpt
 		edit.SetHImage(opt.HImage)
 	} else { // 确定 iconFa 图标和字体类型
 		if opt.IconUnicode > 0 {
-			edit.SetIconUnicode(opt.IconUnicode)
+			edit.SetIconUnicode(opt.IconUnicode, opt.IconStyle)
 		} else if opt.IconHex != "" {
-			edit.SetIconHex(opt.IconHex)
+			edit.SetIconHex(opt.IconHex, opt.IconStyle)
 		} else if opt.Icon != "" {
 			edit.SetIconName(opt.Icon)
-		} else { // 无图标
-			hasIcon = false
 		}
 	}
-
-	if hasIcon { // 有图标
-		if opt.IsRight {
-			edit.SetBorderSize(15, 0, 29, 0)
-		} else {
-			edit.SetBorderSize(29, 0, 15, 0)
-		}
-		edit.EnableRight(opt.IsRight)
-		edit.EnableAutoColor(opt.IsAutoColor)
-	} else {
-		edit.SetBorderSize(15, 0, 15, 0)
+	edit.EnableRight(opt.IsRight)
+	edit.EnableAutoColor(opt.IsAutoColor)
+	// 设置是否是可切换显示的密码框
+	if opt.ShowPassword {
+		edit.EnableShowPassword(true)
 	}
+	// 设置是否可清空, 同时设置边框大小
+	edit.EnableClearable(opt.Clearable)
+	edit.SetOnClear(opt.OnClear)
+	// 设置前置和后置内容, 同时设置边框大小
+	edit.setSlot(editSlot_Prepend, opt.Prepend)
+	edit.setSlot(editSlot_Append, opt.Append)
 
-	edit.SetProperty("element-func-draw-ele", "onDrawEdit")
+	edit.SetPainter("onDrawEdit")
 
 	// 注册元素鼠标进入事件
 	edit.Event_MOUSESTAY1(onMouseStayEle)
 	// 注册元素鼠标离开事件
 	edit.Event_MOUSELEAVE1(onMouseLeaveEle)
+	// 注册元素鼠标移动事件, 用于显示鼠标停留的功能图标
+	edit.Event_MOUSEMOVE1(onEditMouseMove)
+	// 注册元素鼠标左键按下事件, 用于点击功能图标
+	edit.Event_LBUTTONDOWN1(onEditLButtonDown)
 	// 注册元素绘制事件
 	edit.Event_PAINT1(onDrawEle)
 	// 注册编辑框光标位置改变事件, 用于在光标移动时重绘
 	edit.Event_EDIT_POS_CHANGED1(onEditPosChanged)
+	// 注册元素大小改变事件, 用于排列前置和后置元素
+	edit.Event_SIZE1(onEditSize)
+	// 注册元素销毁事件
+	edit.Event_DESTROY1(onDestroyEle)
 	return edit
 }
 
@@ -156,46 +169,284 @@ func (e *Edit) SetSizeEle(size int) *Edit {
 	return e
 }
 
-// SetRound 设置编辑框的圆角大小, 没有设置时的默认圆角是 4.
+// SetRound 设置编辑框的圆角大小, 没有设置时的默认圆角是 Theme.BorderRadiusBase.
 //
 // round: 圆角大小, 小于 1 时为直角.
 func (e *Edit) SetRound(round int32) *Edit {
 	if round < 0 {
 		round = 0
 	}
-	e.SetProperty("element-round", xc.Itoa(round*e.dpi/96))
+	if s := e.edState(); s != nil {
+		s.setRound(round * e.dpi / 96)
+	}
 	return e
 }
 
-// GetRound 获取编辑框的圆角大小.
+// GetRound 获取编辑框的圆角大小. 四个角不同时返回最大的.
 func (e *Edit) GetRound() int32 {
-	return xc.Atoi(e.GetProperty("element-round")) * 96 / e.dpi
+	if s := e.edState(); s != nil {
+		return s.round * 96 / e.dpi
+	}
+	return 0
+}
+
+// SetRoundEx 分别设置编辑框四个角的圆角大小, 会覆盖 SetRound 的设置, 内部未重绘.
+//
+// leftTop, rightTop, rightBottom, leftBottom: 左上角, 右上角, 右下角, 左下角的圆角大小, 小于 1 时为直角.
+func (e *Edit) SetRoundEx(leftTop, rightTop, rightBottom, leftBottom int32) *Edit {
+	corners := Corners{LeftTop: leftTop, RightTop: rightTop, RightBottom: rightBottom, LeftBottom: leftBottom}
+	if s := e.edState(); s != nil {
+		s.setRoundEx(corners.scale(e.dpi, 96))
+	}
+	return e
+}
+
+// GetRoundEx 获取编辑框四个角的圆角大小. 使用 SetRound 设置时四个角相同.
+func (e *Edit) GetRoundEx() Corners {
+	if s := e.edState(); s != nil {
+		return s.corners().scale(96, e.dpi)
+	}
+	return Corners{}
 }
 
 // EnableRight 设置编辑框的图标是否在右边.
 //
 // isRight: 图标是否在右边.
 func (e *Edit) EnableRight(isRight bool) *Edit {
-	e.SetProperty("element-icon-right", common.BoolToString(isRight))
+	if s := e.edState(); s != nil {
+		s.iconRight = isRight
+	}
 	return e
 }
 
 // IsRight 判断编辑框的图标是否在右边.
 func (e *Edit) IsRight() bool {
-	return e.GetProperty("element-icon-right") == "true"
+	if s := e.edState(); s != nil {
+		return s.iconRight
+	}
+	return false
 }
 
 // EnableAutoColor 设置编辑框的图标颜色是否根据焦点颜色自动改变.
 //
 // isAutoColor: 图标颜色是否根据焦点颜色自动改变.
 func (e *Edit) EnableAutoColor(isAutoColor bool) *Edit {
-	e.SetProperty("element-icon-autocolor", common.BoolToString(isAutoColor))
+	if s := e.edState(); s != nil {
+		s.autoColor = isAutoColor
+	}
 	return e
 }
 
 // IsAutoColor 判断编辑框的图标颜色是否根据焦点颜色自动改变.
 func (e *Edit) IsAutoColor() bool {
-	return e.GetProperty("element-icon-autocolor") == "true"
+	if s := e.edState(); s != nil {
+		return s.autoColor
+	}
+	return false
+}
+
+// EnableClearable 设置编辑框是否可清空, 内部未重绘.
+//   - 可清空时, 编辑框有内容并且鼠标停留或拥有焦点时, 右边会显示清空图标, 点击清空内容.
+//   - 会重新设置左右边框大小, 让文字不会显示在清空图标下面, 之前调用 SetBorderSize 的设置会被覆盖.
+//
+// isClearable: 是否可清空.
+func (e *Edit) EnableClearable(isClearable bool) *Edit {
+	s := e.edState()
+	if s == nil {
+		return e
+	}
+	s.clearable = isClearable
+	if isClearable {
+		s.clearIcon = e.newFaIcon(iconEditClear)
+	}
+	return e.updateBorderSize()
+}
+
+// IsClearable 判断编辑框是否可清空.
+func (e *Edit) IsClearable() bool {
+	if s := e.edState(); s != nil {
+		return s.clearable
+	}
+	return false
+}
+
+// SetOnClear 设置点击清空图标清空内容后调用的函数.
+//
+// f: 清空后调用的函数.
+func (e *Edit) SetOnClear(f func()) *Edit {
+	if s := e.edState(); s != nil {
+		s.onClear = f
+	}
+	return e
+}
+
+// EnableShowPassword 设置编辑框是否是可切换显示的密码框, 内部未重绘.
+//   - 启用时进入密码模式, 右边显示眼睛图标, 点击切换显示或隐藏密码. 关闭时退出密码模式.
+//   - 会重新设置左右边框大小, 之前调用 SetBorderSize 的设置会被覆盖.
+//
+// isShowPassword: 是否是可切换显示的密码框.
+func (e *Edit) EnableShowPassword(isShowPassword bool) *Edit {
+	s := e.edState()
+	if s == nil {
+		return e
+	}
+	s.showPassword = isShowPassword
+	s.passwordVisible = false
+	if isShowPassword {
+		s.passwordIcons = [2]faIcon{e.newFaIcon(iconEditPasswordShow), e.newFaIcon(iconEditPasswordHide)}
+	}
+	e.EnablePassword(isShowPassword)
+	return e.updateBorderSize()
+}
+
+// IsShowPassword 判断编辑框是否是可切换显示的密码框.
+func (e *Edit) IsShowPassword() bool {
+	if s := e.edState(); s != nil {
+		return s.showPassword
+	}
+	return false
+}
+
+// SetPasswordVisible 设置可切换显示的密码框是否显示密码, 内部已自动重绘. 不是可切换显示的密码框时无效.
+//
+// isVisible: 是否显示密码.
+func (e *Edit) SetPasswordVisible(isVisible bool) *Edit {
+	setPasswordVisible(e.H, isVisible)
+	return e
+}
+
+// IsPasswordVisible 判断可切换显示的密码框是否正在显示密码.
+func (e *Edit) IsPasswordVisible() bool {
+	if s := e.edState(); s != nil {
+		return s.passwordVisible
+	}
+	return false
+}
+
+// updateBorderSize 根据图标和功能图标设置左右边框大小, 即文字与左右边的距离. 上下边框大小是 paddingY.
+func (e *Edit) updateBorderSize() *Edit {
+	t := e.getTheme()
+	s := e.edState()
+	if s == nil {
+		return e
+	}
+	var iconCx int32
+	if s.hSvg > 0 {
+		iconCx = xc.XSvg_GetWidth(s.hSvg)
+	} else if s.hImage > 0 {
+		iconCx = xc.XImage_GetWidth(s.hImage)
+	} else if s.iconFa != "" {
+		iconCx = s.iconFaCx
+	}
+
+	left, right := t.PaddingInput, t.PaddingInput
+	if iconCx > 0 { // 有图标
+		paddingIcon := t.PaddingInputIcon
+		// 图标变大后文字要让出位置
+		if cx := s.iconFaCx * 96 / e.dpi; s.iconSize > 0 && t.SpaceInputIcon*2+cx+t.SpaceIconText > paddingIcon {
+			paddingIcon = t.SpaceInputIcon*2 + cx + t.SpaceIconText
+		}
+		if s.iconRight {
+			right = paddingIcon
+		} else {
+			left = paddingIcon
+		}
+	}
+	if s.clearable || s.showPassword {
+		// 功能图标最左边再留出一个间距
+		_, _, toolsLeft := s.toolRects(0, 0, iconCx)
+		if r := (s.spaceLeft - toolsLeft) * 96 / e.dpi; r > right {
+			right = r
+		}
+	}
+	slotLeft, slotRight := s.slotBorders(e.dpi)
+	left += slotLeft
+	right += slotRight
+	e.SetBorderSize(left, s.paddingY, right, s.paddingY)
+	return e
+}
+
+// GetPrepend 获取前置元素的句柄, 没有时返回 0. 前置内容是文字或图标时, 返回的是内部创建的按钮.
+func (e *Edit) GetPrepend() int {
+	if s := e.edState(); s != nil {
+		return s.slots[editSlot_Prepend].hEle
+	}
+	return 0
+}
+
+// GetAppend 获取后置元素的句柄, 没有时返回 0. 后置内容是文字或图标时, 返回的是内部创建的按钮.
+func (e *Edit) GetAppend() int {
+	if s := e.edState(); s != nil {
+		return s.slots[editSlot_Append].hEle
+	}
+	return 0
+}
+
+// setSlot 设置前置或后置内容, 把元素放到编辑框中排列好, 然后设置边框大小.
+//   - 文字或图标会创建一个不获取焦点的按钮, 使用 onDrawEditSlot 绘制.
+//   - 其它元素会成为编辑框的子元素, 高度与编辑框相同, 与输入部分相接的角变为直角.
+//
+// i: 前置或后置, 是 editSlot_ 常量.
+//
+// slot: 前置或后置内容, 为空时不设置.
+func (e *Edit) setSlot(i int, slot EditSlot) {
+	if slot.HEle == 0 && slot.Text == "" && slot.Icon == "" {
+		return
+	}
+	// 元素与输入部分相接的边
+	side := side_Right
+	if i == editSlot_Append {
+		side = side_Left
+	}
+
+	var item editSlot
+	if slot.HEle > 0 {
+		item = editSlot{hEle: slot.HEle, cx: xc.XEle_GetWidth(slot.HEle), ownBorder: true}
+		xc.XEle_AddChild(e.H, slot.HEle)
+		switch st := stateMap[slot.HEle].(type) {
+		case nil:
+		case *buttonState:
+			st.joinSides = side
+		default:
+			st.base().setRoundEx(st.base().corners().squareSides(side))
+		}
+	} else {
+		btn := e.eui.CreateButton(slot.Text, e.H, ButtonOption{Icon: slot.Icon})
+		bs := btn.btnState()
+		bs.painter = "onDrawEditSlot"
+		bs.joinSides = side
+		// 前置/后置文字只是说明, 不获取焦点
+		xc.XEle_EnableFocus(btn.H, false)
+		t := e.getTheme()
+		var textSize xc.SIZE
+		if slot.Text != "" {
+			hFont := xc.XEle_GetFont(btn.H)
+			if hFont == 0 {
+				hFont = xc.XC_GetDefaultFont()
+			}
+			xc.XC_GetTextShowSize(slot.Text, utf16Len(slot.Text), hFont, &textSize)
+		}
+		cx := editSlotWidth(Size{CX: bs.iconFaCx, CY: bs.iconFaCy}, Size(textSize), t.SpaceIconText*e.dpi/96, t.PaddingInputSlot*e.dpi/96)
+		item = editSlot{hEle: btn.H, cx: cx}
+	}
+
+	// 元素的鼠标停留和焦点反映到编辑框的边框上
+	ele := widget.NewElementByHandle(item.hEle)
+	ele.Event_MOUSESTAY1(onEditSlotMouseStay)
+	ele.Event_MOUSELEAVE1(onEditSlotMouseLeave)
+	ele.Event_SETFOCUS1(onEditSlotSetFocus)
+	ele.Event_KILLFOCUS1(onEditSlotKillFocus)
+
+	if s := e.edState(); s != nil {
+		s.slots[i] = item
+	}
+	layoutEditSlots(e.H)
+	e.updateBorderSize()
+}
+
+// edState 获取编辑框的状态. 编辑框没有记录状态时返回 nil.
+func (e *Edit) edState() *editState {
+	return getEditState(e.H)
 }
 
 // EditOption 编辑框选项.
@@ -213,11 +464,18 @@ type EditOption struct {
 	// Font Wesome 图标对应的 Unicode 码点十六进制文本, 如'f1b0'相当于'fa-solid fa-paw'.
 	// 	- 注意: HSvg, HImage, IconUnicode, IconHex, Icon 这几个参数只需要填一个即可, 填多个的话, 生效顺序优先级为: HSvg > HImage > IconUnicode > IconHex > Icon.
 	IconHex string
+	// 使用 IconUnicode 或 IconHex 时优先使用的风格, 如'fa-regular'. 同一个码点可能有多个风格, 如 address-book 有'fa-solid'和'fa-regular'.
+	//  - 为空或图标没有这个风格时按'fa-solid', 'fa-brands', 'fa-regular'的顺序选择.
+	IconStyle string
 	// Font Wesome 图标名.
 	//  - 如'fa-solid fa-paw', 前面是风格, 后面是图标名, 用空格分开, 其中风格可省略, 没有风格时会自动根据'fa-solid', 'fa-brands', 'fa-regular'的顺序尝试添加风格.
 	//  - 图标大全: https://fa6.dashgame.com, 在网页里点导航栏图标, 然后点免费, 可筛选出 2000+ 免费图标, 点击图标会复制完整风格+图标名到剪贴板, 可直接使用. 内置 FontAwesome 版本为 6.6.0
 	// 	- 注意: HSvg, HImage, IconUnicode, IconHex, Icon 这几个参数只需要填一个即可, 填多个的话, 生效顺序优先级为: HSvg > HImage > IconUnicode > IconHex > Icon.
 	Icon string
+	// 图标大小, 单位与 NewElementui 的 fontSize 相同, 只对 Font Awesome 图标有效. 为 0 时使用 fontSize.
+	IconSize int32
+	// 图标颜色, xc.RGBA 的返回值, 对 Font Awesome 图标和 HSvg 有效. 为 0 时使用默认颜色.
+	IconColor uint32
 
 	// 当无内容时显示的文本.
 	DefaultText string
@@ -238,147 +496,156 @@ type EditOption struct {
 	// 图标颜色是否根据焦点颜色自动改变.
 	//  - 如果你使用的是 HImage, 则此参数无效.
 	IsAutoColor bool
+	// 是否可清空, 有内容并且鼠标停留或拥有焦点时在右边显示清空图标, 点击清空内容.
+	Clearable bool
+	// 点击清空图标清空内容后调用的函数, 也可以用 SetOnClear 设置.
+	OnClear func()
+	// 是否是可切换显示的密码框, 输入的内容显示为密码, 右边显示眼睛图标, 点击切换显示或隐藏密码.
+	ShowPassword bool
+
+	// 前置内容, 如'http://', 与输入部分共用边框, 相接的角是直角.
+	Prepend EditSlot
+	// 后置内容, 如'.com'或搜索按钮, 与输入部分共用边框, 相接的角是直角.
+	Append EditSlot
+}
+
+// EditSlot 编辑框的前置或后置内容, 可以是文字, 图标或其它元素.
+//   - 填写了 HEle 时忽略 Text 和 Icon.
+//   - 元素的鼠标停留和焦点会反映到编辑框的边框上.
+type EditSlot struct {
+	// 文字, 如'http://'.
+	Text string
+	// Font Wesome 图标名, 如'fa-solid fa-magnifying-glass', 可与 Text 一起使用, 图标在左边.
+	Icon string
+	// 其它元素的句柄, 如 CreateButton 创建的按钮. 元素会被移动到编辑框中, 宽度不变, 高度与编辑框相同.
+	HEle int
 }
 
 // https://element.eleme.cn/2.14/#/zh-CN/component/input
-// todo: Clearable 可清空的输入框
-// todo: IsPassword 可切换显示隐藏的密码框
-// todo: Textarea 多行输入框
-// todo: 复合型输入框
-// todo: 带输入建议
-
-// 编辑框尺寸. 已经预设好的.
-
-const (
-	EditSize_Default = iota + 1 // 180x40
-	EditSize_Mdeium             // 180x36
-	EditSize_Small              // 180x32
-	EditSize_Mini               // 180x28
-)
 
-// 编辑框绘制事件.
-func onDrawEdit(hEle int, hDraw int, pbHandled *bool) int {
-	*pbHandled = true
-	eleWidth := xc.XEle_GetWidth(hEle)
-	eleHeight := xc.XEle_GetHeight(hEle)
-	var rc xc.RECT
-	rc.Right = eleWidth
-	rc.Bottom = eleHeight
-	xc.XDraw_EnableSmoothingMode(hDraw, true)
-
-	nState := xc.Atoi(xc.XC_GetProperty(hEle, "element-mouse-state"))
-	var borderColor uint32
-	if xc.XEle_IsFocus(hEle) { // 判断是否拥有焦点改变边框颜色和文本颜色
-		borderColor = xc.RGBA(64, 158, 255, 255)
-		if xc.XEle_GetStateFlags(hEle) == xcc.Element_State_Flag_Down {
-			xc.XEle_SetTextColor(hEle, xc.RGBA(193, 196, 203, 255))
-		} else {
-			xc.XEle_SetTextColor(hEle, xc.RGBA(96, 98, 102, 255))
-		}
-	} else if nState == 0 {
-		borderColor = xc.RGBA(220, 223, 229, 255)
-	} else if nState == 1 {
-		borderColor = xc.RGBA(193, 196, 203, 255)
+// 元素鼠标进入事件
+func onMouseStayEle(hEle int, pbHandled *bool) int {
+	if s := getState(hEle); s != nil {
+		s.mouseStay = true
 	}
-	bgColor := xcc.COLOR_WHITE
+	xc.XEle_Redraw(hEle, false)
+	return 0
+}
 
-	if !xc.XEle_IsEnable(hEle) { // 元素为禁用状态改变各种颜色
-		borderColor = xc.RGBA(228, 231, 237, 255)
-		bgColor = xc.RGBA(245, 247, 250, 255)
-		xc.XEle_SetTextColor(hEle, xc.RGBA(193, 196, 203, 255))
-	} else {
-		xc.XEle_SetTextColor(hEle, xc.RGBA(96, 98, 102, 255))
+// 元素鼠标离开事件
+func onMouseLeaveEle(hEle int, hEleStay int, pbHandled *bool) int {
+	if s := getState(hEle); s != nil {
+		s.mouseStay = false
 	}
+	xc.XEle_Redraw(hEle, false)
+	return 0
+}
 
-	round := xc.Atoi(xc.XC_GetProperty(hEle, "element-round"))
-	// 绘制圆角矩形边框
-	xc.XDraw_SetBrushColor(hDraw, borderColor)
-	xc.XDraw_DrawRoundRect(hDraw, &rc, round, round)
-
-	// 绘制填充圆角矩形
-	xc.XDraw_SetBrushColor(hDraw, bgColor)
-	rc.Top = 1
-	rc.Left = 1
-	rc.Right = rc.Right - 1
-	rc.Bottom = rc.Bottom - 1
-	xc.XDraw_FillRoundRect(hDraw, &rc, round, round)
+// 编辑框大小改变事件, 重新排列前置和后置元素
+func onEditSize(hEle int, nFlags xcc.AdjustLayout_, nAdjustNo uint32, pbHandled *bool) int {
+	layoutEditSlots(hEle)
+	return 0
+}
 
-	IsRight := xc.XC_GetProperty(hEle, "element-icon-right") == "true"
-	AutoColor := xc.XC_GetProperty(hEle, "element-icon-autocolor") == "true"
-	spaceLeft := round
-	if spaceLeft < 1 {
-		spaceLeft = xc.Atoi(xc.XC_GetProperty(hEle, "element-space-left"))
+// layoutEditSlots 按编辑框的大小排列前置和后置元素.
+func layoutEditSlots(hEle int) {
+	s := getEditState(hEle)
+	if s == nil {
+		return
 	}
-
-	if hSvg, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-icon-hsvg")); hSvg > 0 && xc.XC_IsHXCGUI(hSvg, xcc.XC_SVG) {
-		if AutoColor { // 图标颜色是否根据焦点颜色自动改变.
-			xc.XSvg_SetUserFillColor(hSvg, borderColor, true)
-		} else {
-			xc.XSvg_SetUserFillColor(hSvg, xc.RGBA(193, 196, 203, 255), true)
-		}
-
-		rc.Top = (eleHeight - xc.XSvg_GetHeight(hSvg)) / 2
-		if IsRight { // 图标是否在右边.
-			svgWidth := xc.XSvg_GetWidth(hSvg)
-			rc.Left = eleWidth - 1 - spaceLeft - svgWidth
-			xc.XDraw_DrawSvg(hDraw, hSvg, rc.Left, rc.Top)
-		} else {
-			rc.Left += spaceLeft
-			xc.XDraw_DrawSvg(hDraw, hSvg, rc.Left, rc.Top)
+	var rcs [2]Rect
+	rcs[editSlot_Prepend], rcs[editSlot_Append] = s.slotRects(xc.XEle_GetWidth(hEle), xc.XEle_GetHeight(hEle))
+	for i, slot := range s.slots {
+		if slot.cx > 0 {
+			rc := xc.RECT(rcs[i])
+			xc.XEle_SetRect(slot.hEle, &rc, false, xcc.AdjustLayout_All, 0)
 		}
-	} else if hImage, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-icon-himage")); hImage > 0 && xc.XC_IsHXCGUI(hImage, xcc.XC_IMAGE_FRAME) {
-		rc.Top = (eleHeight - xc.XImage_GetHeight(hImage)) / 2
-		if IsRight { // 图标是否在右边.
-			imageWidth := xc.XImage_GetWidth(hImage)
-			rc.Left = eleWidth - 1 - spaceLeft - imageWidth
-			xc.XDraw_Image(hDraw, hImage, rc.Left, rc.Top)
-		} else {
-			rc.Left += spaceLeft
-			xc.XDraw_Image(hDraw, hImage, rc.Left, rc.Top)
-		}
-	} else if iconFa := xc.XC_GetProperty(hEle, "element-icon-fa"); iconFa != "" {
-		hFontAwesome, _ := strconv.Atoi(xc.XC_GetProperty(hEle, "element-hfontawesome"))
-		xc.XDraw_SetFont(hDraw, hFontAwesome)
-		xc.XDraw_SetTextAlign(hDraw, xcc.TextAlignFlag_Vcenter|xcc.TextFormatFlag_NoWrap|xcc.TextAlignFlag_Center)
+	}
+}
 
-		if AutoColor { // 图标颜色是否根据焦点颜色自动改变.
-			xc.XDraw_SetBrushColor(hDraw, borderColor)
-		} else {
-			xc.XDraw_SetBrushColor(hDraw, xc.RGBA(193, 196, 203, 255))
-		}
+// 编辑框前置/后置元素鼠标进入事件, 编辑框显示为鼠标停留的样子
+func onEditSlotMouseStay(hEle int, pbHandled *bool) int {
+	updateSlotState(hEle, func(s *editState) { s.slotStay = true })
+	return 0
+}
 
-		hFontAwesomeShowSizeCx := xc.Atoi(xc.XC_GetProperty(hEle, "element-hfontawesome-showsize-cx"))
-		if IsRight { // 图标是否在右边.
-			rc.Left = eleWidth - 1 - spaceLeft - hFontAwesomeShowSizeCx
-			rc.Right = rc.Left + hFontAwesomeShowSizeCx
-			xc.XDraw_DrawText(hDraw, iconFa, &rc)
-		} else {
-			rc.Left += spaceLeft
-			rc.Right = rc.Left + hFontAwesomeShowSizeCx
-			xc.XDraw_DrawText(hDraw, iconFa, &rc)
-		}
-		defaultFont := xc.XC_GetDefaultFont()
-		xc.XDraw_SetFont(hDraw, defaultFont)
-	}
+// 编辑框前置/后置元素鼠标离开事件
+func onEditSlotMouseLeave(hEle int, hEleStay int, pbHandled *bool) int {
+	updateSlotState(hEle, func(s *editState) { s.slotStay = false })
 	return 0
 }
 
-// 元素鼠标进入事件
-func onMouseStayEle(hEle int, pbHandled *bool) int {
-	xc.XC_SetProperty(hEle, "element-mouse-state", "1")
-	xc.XEle_Redraw(hEle, false)
+// 编辑框前置/后置元素获得焦点事件, 编辑框显示为拥有焦点的样子
+func onEditSlotSetFocus(hEle int, pbHandled *bool) int {
+	updateSlotState(hEle, func(s *editState) { s.slotFocus = true })
 	return 0
 }
 
-// 元素鼠标离开事件
-func onMouseLeaveEle(hEle int, hEleStay int, pbHandled *bool) int {
-	xc.XC_SetProperty(hEle, "element-mouse-state", "0")
-	xc.XEle_Redraw(hEle, false)
+// 编辑框前置/后置元素失去焦点事件
+func onEditSlotKillFocus(hEle int, pbHandled *bool) int {
+	updateSlotState(hEle, func(s *editState) { s.slotFocus = false })
 	return 0
 }
 
+// updateSlotState 修改前置/后置元素所在编辑框的状态, 然后重绘编辑框.
+//
+// hSlot: 前置/后置元素句柄.
+//
+// f: 修改状态的函数.
+func updateSlotState(hSlot int, f func(s *editState)) {
+	hEdit := xc.XEle_GetParentEle(hSlot)
+	if s := getEditState(hEdit); s != nil {
+		f(s)
+		xc.XEle_Redraw(hEdit, false)
+	}
+}
+
 // 编辑框光标位置改变事件
 func onEditPosChanged(hEle int, iPos int32, pbHandled *bool) int {
 	xc.XEle_Redraw(hEle, false)
 	return 0
 }
+
+// 编辑框鼠标移动事件, 记录鼠标停留的功能图标
+func onEditMouseMove(hEle int, nFlags uint32, pPt *xc.POINT, pbHandled *bool) int {
+	if s := getEditState(hEle); s != nil {
+		if tool := s.hitTool(pPt.X, pPt.Y); tool != s.toolHover {
+			s.toolHover = tool
+			xc.XEle_Redraw(hEle, false)
+		}
+	}
+	return 0
+}
+
+// 编辑框鼠标左键按下事件, 点击功能图标时拦截, 不移动光标
+func onEditLButtonDown(hEle int, nFlags uint32, pPt *xc.POINT, pbHandled *bool) int {
+	s := getEditState(hEle)
+	if s == nil {
+		return 0
+	}
+	switch s.hitTool(pPt.X, pPt.Y) {
+	case editTool_Clear:
+		s.clear(func(text string) { xc.XEdit_SetText(hEle, text) })
+		xc.XEle_SetFocus(hEle)
+		xc.XEle_Redraw(hEle, false)
+		*pbHandled = true
+	case editTool_Password:
+		setPasswordVisible(hEle, !s.passwordVisible)
+		xc.XEle_SetFocus(hEle)
+		*pbHandled = true
+	}
+	return 0
+}
+
+// setPasswordVisible 切换可切换显示的密码框是否显示密码, 然后重绘.
+//
+// isVisible: 是否显示密码.
+func setPasswordVisible(hEle int, isVisible bool) {
+	s := getEditState(hEle)
+	if s == nil || !s.showPassword {
+		return
+	}
+	s.passwordVisible = isVisible
+	xc.XEdit_EnablePassword(hEle, !isVisible)
+	xc.XEle_Redraw(hEle, false)
+}
@@ -0,0 +1,340 @@
+package eui
+
+// 编辑框尺寸. 已经预设好的.
+
+const (
+	EditSize_Default = iota + 1 // 180x40
+	EditSize_Mdeium             // 180x36
+	EditSize_Small              // 180x32
+	EditSize_Mini               // 180x28
+)
+
+// 编辑框的前置和后置元素, 是 editState.slots 的下标.
+
+const (
+	editSlot_Prepend = iota // 前置元素
+	editSlot_Append         // 后置元素
+)
+
+// 编辑框右边的功能图标.
+
+const (
+	editTool_None     = iota // 不是功能图标
+	editTool_Clear           // 清空图标
+	editTool_Password        // 切换显示密码图标
+)
+
+// 编辑框绘制事件.
+func onDrawEdit(ctx *PaintContext) {
+	cv := ctx.Canvas
+	eleHeight := ctx.Height
+
+	s, ok := ctx.state.(*editState)
+	if !ok {
+		return
+	}
+	// 有前置或后置元素时只绘制它们中间的输入部分
+	rc := s.inputRect(ctx.Width, eleHeight)
+	inputRight := rc.Right
+	theme := ctx.Theme
+	borderColor, bgColor := editColors(ctx, s)
+
+	corners := s.inputCorners()
+	// 绘制圆角矩形边框
+	cv.SetBrushColor(borderColor)
+	cv.DrawRoundRectEx(rc, corners)
+
+	// 绘制填充圆角矩形
+	cv.SetBrushColor(bgColor)
+	rc.Top = 1
+	rc.Left = rc.Left + 1
+	rc.Right = rc.Right - 1
+	rc.Bottom = rc.Bottom - 1
+	cv.FillRoundRectEx(rc, corners)
+
+	IsRight := s.iconRight
+	AutoColor := s.autoColor
+	spaceLeft := s.iconSpace(IsRight)
+	iconColor := theme.ColorTextPlaceholder
+	if AutoColor { // 图标颜色是否根据焦点颜色自动改变.
+		iconColor = borderColor
+	}
+	iconColor = ctx.IconColor(iconColor)
+	drawEditTools(ctx, s, borderColor)
+
+	if svgSize, ok := cv.SvgSize(s.hSvg); s.hSvg > 0 && ok {
+		rc.Top = (eleHeight - svgSize.CY) / 2
+		if IsRight { // 图标是否在右边.
+			rc.Left = inputRight - 1 - spaceLeft - svgSize.CX
+			cv.DrawSvg(s.hSvg, rc.Left, rc.Top, iconColor)
+		} else {
+			rc.Left += spaceLeft
+			cv.DrawSvg(s.hSvg, rc.Left, rc.Top, iconColor)
+		}
+	} else if imgSize, ok := cv.ImageSize(s.hImage); s.hImage > 0 && ok {
+		rc.Top = (eleHeight - imgSize.CY) / 2
+		if IsRight { // 图标是否在右边.
+			rc.Left = inputRight - 1 - spaceLeft - imgSize.CX
+			cv.DrawImage(s.hImage, rc.Left, rc.Top)
+		} else {
+			rc.Left += spaceLeft
+			cv.DrawImage(s.hImage, rc.Left, rc.Top)
+		}
+	} else if iconFa := s.iconFa; iconFa != "" {
+		cv.SetFont(s.hFontAwesome)
+		cv.SetTextAlign(TextAlign_VCenter | TextAlign_NoWrap | TextAlign_Center)
+		cv.SetBrushColor(iconColor)
+
+		hFontAwesomeShowSizeCx := s.iconFaCx
+		if IsRight { // 图标是否在右边.
+			rc.Left = inputRight - 1 - spaceLeft - hFontAwesomeShowSizeCx
+			rc.Right = rc.Left + hFontAwesomeShowSizeCx
+			cv.DrawText(iconFa, rc)
+		} else {
+			rc.Left += spaceLeft
+			rc.Right = rc.Left + hFontAwesomeShowSizeCx
+			cv.DrawText(iconFa, rc)
+		}
+		cv.SetFont(0)
+	}
+}
+
+// editColors 根据焦点, 鼠标停留和启用状态返回编辑框的边框颜色和背景颜色, 同时设置 ctx.TextColor.
+func editColors(ctx *PaintContext, s *editState) (borderColor, bgColor uint32) {
+	theme := ctx.Theme
+	// 前置或后置元素的焦点和鼠标停留也算在编辑框上
+	if ctx.Focus || s.slotFocus { // 判断是否拥有焦点改变边框颜色
+		borderColor = theme.ColorPrimary
+	} else if !s.mouseStay && !s.slotStay {
+		borderColor = theme.BorderColorBase
+	} else {
+		borderColor = theme.ColorTextPlaceholder
+	}
+	bgColor = theme.BackgroundColor
+
+	if !ctx.Enable { // 元素为禁用状态改变各种颜色
+		borderColor = theme.BorderColorLight
+		bgColor = theme.BackgroundColorBase
+		ctx.TextColor = theme.ColorTextPlaceholder
+	} else {
+		ctx.TextColor = theme.ColorTextRegular
+	}
+	return borderColor, bgColor
+}
+
+// iconSpace 返回图标与左边或右边边框的间距, 是那一边的圆角大小, 直角时是 spaceLeft.
+//
+// right: 是否右边.
+func (s *editState) iconSpace(right bool) int32 {
+	corners := s.inputCorners()
+	space := corners.LeftTop
+	if corners.LeftBottom > space {
+		space = corners.LeftBottom
+	}
+	if right {
+		space = corners.RightTop
+		if corners.RightBottom > space {
+			space = corners.RightBottom
+		}
+	}
+	if space < 1 {
+		space = s.spaceLeft
+	}
+	return space
+}
+
+// toolRects 计算编辑框右边功能图标的位置, 从右往左依次是: 右边的图标, 切换显示密码图标, 清空图标, 相互间隔 spaceLeft.
+//   - 功能图标不管是否显示都占着位置, 这样文字不会跑到图标下面.
+//
+// width, height: 编辑框输入部分的右边和高度, 没有后置元素时是编辑框宽高.
+//
+// iconCx: 元素图标的宽度, 图标不在右边时不使用.
+//
+// 返回清空图标和切换显示密码图标的位置, 没有启用时为空, 以及所有功能图标和右边图标的最左边.
+func (s *editState) toolRects(width, height, iconCx int32) (rcClear, rcPassword Rect, left int32) {
+	left = width - 1 - s.iconSpace(true)
+	if s.iconRight && iconCx > 0 {
+		left -= iconCx + s.spaceLeft
+	}
+	place := func(icon faIcon) Rect {
+		top := (height - icon.cy) / 2
+		rc := Rect{Left: left - icon.cx, Top: top, Right: left, Bottom: top + icon.cy}
+		left = rc.Left - s.spaceLeft
+		return rc
+	}
+	if s.showPassword {
+		rcPassword = place(s.passwordIcon())
+	}
+	if s.clearable {
+		rcClear = place(s.clearIcon)
+	}
+	return rcClear, rcPassword, left
+}
+
+// passwordIcon 返回当前要显示的切换图标, 隐藏密码时是显示密码图标, 显示密码时是隐藏密码图标.
+func (s *editState) passwordIcon() faIcon {
+	if s.passwordVisible {
+		return s.passwordIcons[1]
+	}
+	return s.passwordIcons[0]
+}
+
+// hitTool 返回坐标处的功能图标, 是 editTool_ 常量. 使用上次绘制时记录的位置.
+func (s *editState) hitTool(x, y int32) int {
+	if s.rcClear.contains(x, y) {
+		return editTool_Clear
+	} else if s.rcPassword.contains(x, y) {
+		return editTool_Password
+	}
+	return editTool_None
+}
+
+// clear 清空编辑框的内容, 清空后再调用 SetOnClear 设置的函数, 这时编辑框已经没有内容了.
+//
+// setText: 设置编辑框内容的函数.
+func (s *editState) clear(setText func(text string)) {
+	setText("")
+	s.hasText = false
+	if s.onClear != nil {
+		s.onClear()
+	}
+}
+
+// drawEditTools 绘制编辑框右边的功能图标, 并记录它们的位置.
+//   - 清空图标在有内容并且鼠标停留或拥有焦点时显示.
+//   - 切换显示密码图标在有内容或拥有焦点时显示, 拥有焦点时使用边框颜色.
+//   - 鼠标停留在功能图标上时图标颜色变深.
+//
+// borderColor: 编辑框的边框颜色, 已根据鼠标停留和焦点确定.
+func drawEditTools(ctx *PaintContext, s *editState, borderColor uint32) {
+	iconCx, _ := ctx.IconSize()
+	rcClear, rcPassword, _ := s.toolRects(s.inputRect(ctx.Width, ctx.Height).Right, ctx.Height, iconCx)
+	s.rcClear, s.rcPassword = Rect{}, Rect{}
+	if !ctx.Enable {
+		return
+	}
+	theme := ctx.Theme
+	draw := func(icon faIcon, rc Rect, tool int, color uint32) {
+		if s.toolHover == tool && s.mouseStay {
+			color = theme.ColorTextSecondary
+		}
+		cv := ctx.Canvas
+		cv.SetFont(icon.hFont)
+		cv.SetTextAlign(TextAlign_VCenter | TextAlign_NoWrap | TextAlign_Center)
+		cv.SetBrushColor(color)
+		cv.DrawText(icon.text, rc)
+		cv.SetFont(0)
+	}
+	if s.showPassword && (s.hasText || ctx.Focus) {
+		s.rcPassword = rcPassword
+		color := theme.ColorTextPlaceholder
+		if ctx.Focus {
+			color = borderColor
+		}
+		draw(s.passwordIcon(), rcPassword, editTool_Password, color)
+	}
+	if s.clearable && s.hasText && (ctx.Focus || s.mouseStay) {
+		s.rcClear = rcClear
+		draw(s.clearIcon, rcClear, editTool_Clear, theme.ColorTextPlaceholder)
+	}
+}
+
+// slotSides 返回有前置或后置元素的边, 是 side_ 常量的组合. 输入部分这些边上的角是直角.
+func (s *editState) slotSides() int {
+	var sides int
+	if s.slots[editSlot_Prepend].cx > 0 {
+		sides |= side_Left
+	}
+	if s.slots[editSlot_Append].cx > 0 {
+		sides |= side_Right
+	}
+	return sides
+}
+
+// inputRect 返回编辑框输入部分的位置, 在前置和后置元素中间. 输入部分的边框与它们共用.
+//
+// width, height: 编辑框宽高.
+func (s *editState) inputRect(width, height int32) Rect {
+	return Rect{Left: s.slots[editSlot_Prepend].cx, Right: width - s.slots[editSlot_Append].cx, Bottom: height}
+}
+
+// inputCorners 返回输入部分四个角的圆角大小, 与前置和后置元素相接的角是直角.
+func (s *editState) inputCorners() Corners {
+	return s.corners().squareSides(s.slotSides())
+}
+
+// slotBorders 返回前置和后置元素要占去的左右边框大小, 已换算回未按 dpi 缩放的大小, 与 SetBorderSize 的单位相同.
+//
+// dpi: 窗口 dpi.
+func (s *editState) slotBorders(dpi int32) (left, right int32) {
+	return s.slots[editSlot_Prepend].cx * 96 / dpi, s.slots[editSlot_Append].cx * 96 / dpi
+}
+
+// slotRects 计算前置和后置元素的位置, 没有时为空.
+//   - 自己绘制相接边框的元素 (如按钮) 与输入部分重叠 1 像素, 盖住输入部分的那条边框.
+//   - 否则元素紧挨着输入部分, 相接的边框由输入部分绘制, 这样输入部分拥有焦点时整条边框都会变色.
+//
+// width, height: 编辑框宽高.
+func (s *editState) slotRects(width, height int32) (rcPrepend, rcAppend Rect) {
+	if slot := s.slots[editSlot_Prepend]; slot.cx > 0 {
+		rcPrepend = Rect{Right: slot.cx, Bottom: height}
+		if slot.ownBorder {
+			rcPrepend.Right++
+		}
+	}
+	if slot := s.slots[editSlot_Append]; slot.cx > 0 {
+		rcAppend = Rect{Left: width - slot.cx, Right: width, Bottom: height}
+		if slot.ownBorder {
+			rcAppend.Left--
+		}
+	}
+	return rcPrepend, rcAppend
+}
+
+// editSlotWidth 计算文字或图标前置/后置元素的宽度.
+//
+// icon, text: 图标和文字的大小, 没有时为 0.
+//
+// gap: 图标和文字的间距, 已按 dpi 缩放, 只有其中一个时不使用.
+//
+// padding: 内容和左右边框的间距, 已按 dpi 缩放.
+func editSlotWidth(icon, text Size, gap, padding int32) int32 {
+	if icon.CX == 0 || text.CX == 0 {
+		gap = 0
+	}
+	return padding*2 + icon.CX + gap + text.CX
+}
+
+// 编辑框前置/后置文字或图标的绘制事件. 背景是 Theme.BackgroundColorBase, 与输入部分相接的那条边框不绘制, 由输入部分绘制.
+func onDrawEditSlot(ctx *PaintContext) {
+	s, ok := ctx.state.(*buttonState)
+	if !ok {
+		return
+	}
+	cv := ctx.Canvas
+	theme := ctx.Theme
+	rc := Rect{Right: ctx.Width, Bottom: ctx.Height}
+	// 相接的边画到元素外面去
+	if s.joinSides&side_Left != 0 {
+		rc.Left--
+	}
+	if s.joinSides&side_Right != 0 {
+		rc.Right++
+	}
+	corners := s.corners().squareSides(s.joinSides)
+	cv.SetBrushColor(theme.BorderColorBase)
+	cv.DrawRoundRectEx(rc, corners)
+	cv.SetBrushColor(theme.BackgroundColorBase)
+	cv.FillRoundRectEx(Rect{Left: rc.Left + 1, Top: 1, Right: rc.Right - 1, Bottom: rc.Bottom - 1}, corners)
+
+	textColor := theme.ColorTextSecondary
+	if !ctx.Enable {
+		textColor = theme.ColorTextPlaceholder
+	}
+	gap := s.iconGap
+	if gap < 1 {
+		gap = theme.SpaceIconText
+	}
+	l := ctx.LayoutContent(Rect{Right: ctx.Width, Bottom: ctx.Height}, ContentOption{IconPosition: s.iconPosition, Gap: gap})
+	ctx.DrawContent(l, textColor, ctx.IconColor(textColor))
+}
@@ -0,0 +1,106 @@
+package eui
+
+import "testing"
+
+func Test_editState_toolRects(t *testing.T) {
+	s := &editState{clearable: true}
+	s.round = 4
+	s.spaceLeft = 4
+	s.clearIcon = faIcon{cx: 14, cy: 18}
+
+	// 清空图标在最右边, 与边框间隔圆角大小
+	rcClear, _, left := s.toolRects(180, 40, 0)
+	if want := (Rect{161, 11, 175, 29}); rcClear != want || left != 157 {
+		t.Errorf("no icon: got %v %d, want %v 157", rcClear, left, want)
+	}
+
+	// 右边有图标时在图标左边
+	s.iconRight = true
+	rcClear, _, left = s.toolRects(180, 40, 16)
+	if want := (Rect{141, 11, 155, 29}); rcClear != want || left != 137 {
+		t.Errorf("right icon: got %v %d, want %v 137", rcClear, left, want)
+	}
+
+	// 切换显示密码图标在清空图标和右边图标中间
+	s.showPassword = true
+	s.passwordIcons = [2]faIcon{{cx: 16, cy: 18}, {cx: 18, cy: 18}}
+	rcClear, rcPassword, left := s.toolRects(180, 40, 16)
+	if rcPassword != (Rect{139, 11, 155, 29}) || rcClear != (Rect{121, 11, 135, 29}) || left != 117 {
+		t.Errorf("password: got %v %v %d", rcPassword, rcClear, left)
+	}
+	s.passwordVisible = true
+	if _, rcPassword, _ = s.toolRects(180, 40, 16); rcPassword.Width() != 18 {
+		t.Errorf("visible password icon width = %d, want 18", rcPassword.Width())
+	}
+
+	// 只有绘制时显示的图标才能点击
+	if tool := s.hitTool(130, 20); tool != editTool_None {
+		t.Errorf("hidden clear icon hit: %d", tool)
+	}
+	s.rcClear, s.rcPassword = rcClear, rcPassword
+	if tool := s.hitTool(130, 20); tool != editTool_Clear {
+		t.Errorf("clear icon not hit: %d", tool)
+	}
+	if tool := s.hitTool(150, 20); tool != editTool_Password {
+		t.Errorf("password icon not hit: %d", tool)
+	}
+}
+
+func Test_editState_clear(t *testing.T) {
+	text := "hello"
+	s := &editState{clearable: true, hasText: true}
+	var got []string
+	s.onClear = func() { got = append(got, text) }
+	s.clear(func(v string) { text = v })
+	// 调用时内容已经清空
+	if len(got) != 1 || got[0] != "" || s.hasText {
+		t.Errorf("onClear saw %q, hasText %v", got, s.hasText)
+	}
+
+	// 没有设置函数时只清空内容
+	s.onClear = nil
+	text = "x"
+	s.clear(func(v string) { text = v })
+	if text != "" {
+		t.Errorf("text = %q after clear", text)
+	}
+}
+
+func Test_editState_slotRects(t *testing.T) {
+	s := &editState{}
+	s.round = 4
+	if rc := s.inputRect(300, 40); rc != (Rect{0, 0, 300, 40}) || s.slotSides() != 0 {
+		t.Errorf("no slot: got %v %d", rc, s.slotSides())
+	}
+
+	// 文字前置元素紧挨着输入部分, 按钮后置元素与输入部分重叠 1 像素
+	s.slots[editSlot_Prepend] = editSlot{cx: editSlotWidth(Size{}, Size{CX: 42, CY: 16}, 4, 20)}
+	s.slots[editSlot_Append] = editSlot{cx: 80, ownBorder: true}
+	rcPrepend, rcAppend := s.slotRects(300, 40)
+	if rcPrepend != (Rect{0, 0, 82, 40}) || rcAppend != (Rect{219, 0, 300, 40}) {
+		t.Errorf("slots: got %v %v", rcPrepend, rcAppend)
+	}
+	if rc := s.inputRect(300, 40); rc != (Rect{82, 0, 220, 40}) {
+		t.Errorf("input: got %v", rc)
+	}
+	if c := s.inputCorners(); c != (Corners{}) {
+		t.Errorf("corners: got %v, want all square", c)
+	}
+
+	// 只有前置元素时右边的角还是圆角
+	s.slots[editSlot_Append] = editSlot{}
+	if c := s.inputCorners(); c != (Corners{RightTop: 4, RightBottom: 4}) {
+		t.Errorf("prepend corners: got %v", c)
+	}
+
+	// dpi 144 时元素宽度和位置是缩放后的像素, 边框大小换算回未缩放的大小
+	s.slots[editSlot_Prepend] = editSlot{cx: editSlotWidth(Size{}, Size{CX: 63, CY: 24}, 4*144/96, 20*144/96)}
+	s.slots[editSlot_Append] = editSlot{cx: 120, ownBorder: true}
+	rcPrepend, rcAppend = s.slotRects(450, 60)
+	if rcPrepend != (Rect{0, 0, 123, 60}) || rcAppend != (Rect{329, 0, 450, 60}) {
+		t.Errorf("dpi 144 slots: got %v %v", rcPrepend, rcAppend)
+	}
+	if left, right := s.slotBorders(144); left != 82 || right != 80 {
+		t.Errorf("dpi 144 borders: got %d %d, want 82 80", left, right)
+	}
+}
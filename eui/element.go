@@ -0,0 +1,158 @@
+//go:build windows
+
+package eui
+
+import (
+	"github.com/twgh/xcgui/widget"
+	"github.com/twgh/xcgui/xc"
+	"github.com/twgh/xcgui/xcc"
+)
+
+// Element 是使用自定义绘制函数的元素, 继承 widget.Element.
+//   - 配合 RegisterPainter 可以做出自己的元素, 与 Button, Edit 共用绘制事件分发, 主题和图标.
+type Element struct {
+	widget.Element
+	objBase
+}
+
+// CreateElement 创建使用自定义绘制函数的元素.
+//   - 内部注册了元素绘制事件, 鼠标进入/离开事件, 元素销毁事件.
+//
+// painter: 绘制函数名, 需先使用 RegisterPainter 注册.
+//
+// hParent: 父元素或父窗口句柄.
+//
+// opts: ElementOption 元素选项, 可不填.
+func (e *Elementui) CreateElement(painter string, hParent int, opts ...ElementOption) *Element {
+	return updateElement(e, false, painter, hParent, 0, opts...)
+}
+
+// ChangeElement 让现有的元素使用自定义绘制函数.
+//   - 可配合界面设计器来使用, 设计器里放元素, 然后在代码里调用改变样式.
+//   - 不要对已经由 Elementui 创建或改变过的按钮, 编辑框调用, 否则它们原有的状态会丢失, 这种情况请使用它们的 SetPainter 方法.
+//
+// hEle: 元素句柄. 如果不是炫彩元素句柄, 函数会返回 nil.
+//
+// painter: 绘制函数名, 需先使用 RegisterPainter 注册.
+//
+// opts: ElementOption 元素选项, 可不填. 改变模式下 X, Y, Width, Height 字段无效.
+func (e *Elementui) ChangeElement(hEle int, painter string, opts ...ElementOption) *Element {
+	return updateElement(e, true, painter, 0, hEle, opts...)
+}
+
+// 修改元素.
+//
+// isChange: true 是改变模式, false 是创建模式.
+//
+// painter: 绘制函数名.
+//
+// hParent: 父元素或父窗口句柄. [创建模式]
+//
+// hEle: 元素句柄. 如果不是炫彩元素句柄, 函数会返回 nil. [改变模式]
+//
+// opts: ElementOption 元素选项, 可不填.
+func updateElement(e *Elementui, isChange bool, painter string, hParent, hEle int, opts ...ElementOption) *Element {
+	if isChange && !xc.XC_IsHXCGUI(hEle, xcc.XC_ELE) {
+		return nil
+	}
+	var opt ElementOption
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	// 创建元素对象
+	ele := &Element{}
+	ele.hFontAwesomeMap = e.hFontAwesomeMap
+	ele.dpi = e.dpi
+	if !isChange {
+		hEle = xc.XEle_Create(opt.X, opt.Y, opt.Width, opt.Height, hParent)
+	}
+	ele.SetHandle(hEle)
+	ele.H = ele.Handle
+	e.addEle(&ele.objBase, &eleState{})
+
+	// 启用背景透明
+	ele.EnableBkTransparent(true)
+	// 设置圆角大小
+	ele.SetRound(e.theme.BorderRadiusBase)
+	// 设置绘制函数名
+	ele.SetPainter(painter)
+
+	// 图标大小和颜色要在设置图标前设置, 这样字体只需要确定一次
+	ele.SetIconSize(opt.IconSize).SetIconColor(opt.IconColor)
+	// 自定义炫彩 svg 句柄优先级最高, 其次是炫彩图片句柄, 再然后是 iconFa
+	if opt.HSvg > 0 && xc.XC_IsHXCGUI(opt.HSvg, xcc.XC_SVG) {
+		ele.SetHSvg(opt.HSvg)
+	} else if opt.HImage > 0 && xc.XC_IsHXCGUI(opt.HImage, xcc.XC_IMAGE_FRAME) {
+		ele.SetHImage(opt.HImage)
+	} else { // 确定 iconFa 图标和字体类型
+		if opt.IconUnicode > 0 {
+			ele.SetIconUnicode(opt.IconUnicode, opt.IconStyle)
+		} else if opt.IconHex != "" {
+			ele.SetIconHex(opt.IconHex, opt.IconStyle)
+		} else if opt.Icon != "" {
+			ele.SetIconName(opt.Icon)
+		}
+	}
+
+	// 注册元素鼠标进入事件
+	ele.Event_MOUSESTAY1(onMouseStayEle)
+	// 注册元素鼠标离开事件
+	ele.Event_MOUSELEAVE1(onMouseLeaveEle)
+	// 注册元素绘制事件
+	ele.Event_PAINT1(onDrawEle)
+	// 注册元素销毁事件
+	ele.Event_DESTROY1(onDestroyEle)
+	return ele
+}
+
+// SetRound 设置元素的圆角大小, 没有设置时的默认圆角是 Theme.BorderRadiusBase. 绘制函数中可通过 PaintContext.Round 获取.
+//
+// round: 圆角大小, 小于 1 时为直角.
+func (e *Element) SetRound(round int32) *Element {
+	if round < 0 {
+		round = 0
+	}
+	if s := e.state(); s != nil {
+		s.setRound(round * e.dpi / 96)
+	}
+	return e
+}
+
+// GetRound 获取元素的圆角大小.
+func (e *Element) GetRound() int32 {
+	if s := e.state(); s != nil {
+		return s.round * 96 / e.dpi
+	}
+	return 0
+}
+
+// ElementOption 元素选项.
+type ElementOption struct {
+	// 自定义炫彩 svg 句柄.
+	// 	- 注意: HSvg, HImage, IconUnicode, IconHex, Icon 这几个参数只需要填一个即可, 填多个的话, 生效顺序优先级为: HSvg > HImage > IconUnicode > IconHex > Icon.
+	HSvg int
+	// 自定义炫彩图片句柄.
+	// 	- 注意: HSvg, HImage, IconUnicode, IconHex, Icon 这几个参数只需要填一个即可, 填多个的话, 生效顺序优先级为: HSvg > HImage > IconUnicode > IconHex > Icon.
+	HImage int
+
+	// Font Wesome 图标对应的 Unicode 码点十进制数字, 如 61872 相当于'fa-solid fa-paw'.
+	// 	- 注意: HSvg, HImage, IconUnicode, IconHex, Icon 这几个参数只需要填一个即可, 填多个的话, 生效顺序优先级为: HSvg > HImage > IconUnicode > IconHex > Icon.
+	IconUnicode int32
+	// Font Wesome 图标对应的 Unicode 码点十六进制文本, 如'f1b0'相当于'fa-solid fa-paw'.
+	// 	- 注意: HSvg, HImage, IconUnicode, IconHex, Icon 这几个参数只需要填一个即可, 填多个的话, 生效顺序优先级为: HSvg > HImage > IconUnicode > IconHex > Icon.
+	IconHex string
+	// 使用 IconUnicode 或 IconHex 时优先使用的风格, 如'fa-regular'. 同一个码点可能有多个风格, 如 address-book 有'fa-solid'和'fa-regular'.
+	//  - 为空或图标没有这个风格时按'fa-solid', 'fa-brands', 'fa-regular'的顺序选择.
+	IconStyle string
+	// Font Wesome 图标名.
+	//  - 如'fa-solid fa-paw', 前面是风格, 后面是图标名, 用空格分开, 其中风格可省略, 没有风格时会自动根据'fa-solid', 'fa-brands', 'fa-regular'的顺序尝试添加风格.
+	// 	- 注意: HSvg, HImage, IconUnicode, IconHex, Icon 这几个参数只需要填一个即可, 填多个的话, 生效顺序优先级为: HSvg > HImage > IconUnicode > IconHex > Icon.
+	Icon string
+	// 图标大小, 单位与 NewElementui 的 fontSize 相同, 只对 Font Awesome 图标有效. 为 0 时使用 fontSize.
+	IconSize int32
+	// 图标颜色, xc.RGBA 的返回值, 对 Font Awesome 图标和 HSvg 有效. 为 0 时使用默认颜色.
+	IconColor uint32
+
+	X, Y, Width, Height int32
+}
@@ -1,29 +1,23 @@
 package eui
 
-import (
-	"github.com/twgh/xcgui/xc"
-	"github.com/twgh/xcgui/xcc"
-)
-
+// Elementui 用于创建 Elementui 风格的元素, 存放字体, dpi 和主题.
 type Elementui struct {
-	hFontAwesomeMap map[string]int // FontAwesome 字体句柄
-	dpi             int32          // 窗口 dpi
+	hFontAwesomeMap map[string]int      // FontAwesome 字体句柄, 也包含 RegisterIconFont 注册的字体
+	iconFontData    map[string][]byte   // RegisterIconFont 注册的字体数据, 用于 IconSvgText 和创建其它大小的字体
+	iconFonts       map[iconFontKey]int // 其它大小的图标字体句柄, 按风格和大小缓存, 由元素共用
+	fontSize        int32               // 图标字体大小
+	dpi             int32               // 窗口 dpi
+	theme           *Theme              // 当前使用的主题
+	lightTheme      *Theme              // 亮色主题
+	darkTheme       *Theme              // 暗色主题
+	themeMode       int                 // 主题模式
+	systemDark      bool                // 系统是否为暗色
 }
 
-// NewElementui 创建 Elementui 对象.
-//
-// fontSize: 字体大小. 一般使用 12.
-//
-// dpi: 窗口 dpi. 使用窗口.GetDPI()获取.
-func NewElementui(fontSize, dpi int32) *Elementui {
-	p := &Elementui{}
-	p.dpi = dpi
-	p.hFontAwesomeMap = make(map[string]int)
-	p.hFontAwesomeMap["fa-solid"] = xc.XFont_CreateFromMem(fontAwesomeSolid, fontSize, xcc.FontStyle_Regular)
-	p.hFontAwesomeMap["fa-brands"] = xc.XFont_CreateFromMem(fontAwesomeBrands, fontSize, xcc.FontStyle_Regular)
-	p.hFontAwesomeMap["fa-regular"] = xc.XFont_CreateFromMem(fontAwesomeRegular, fontSize, xcc.FontStyle_Regular)
-	xc.XC_SetTextRenderingHint(xcc.TextRenderingHintAntiAliasGridFit)
-	return p
+// iconFontKey 是图标字体缓存的键.
+type iconFontKey struct {
+	style string // 风格, 如'fa-solid'
+	size  int32  // 字体大小
 }
 
 // GetFont 返回 FontAwesome 炫彩字体句柄 map.
@@ -32,8 +26,22 @@ func NewElementui(fontSize, dpi int32) *Elementui {
 //   - fa-solid
 //   - fa-brands
 //   - fa-regular
+//   - RegisterIconFont 注册的风格
 func (e *Elementui) GetFont() map[string]int {
 	return e.hFontAwesomeMap
 }
 
-// todo 用go来写显示所有图标, 根据json分类, 可搜索, 可复制名字, 十六进制, 十进制
+// GetTheme 返回 Elementui 当前使用的主题.
+func (e *Elementui) GetTheme() *Theme {
+	return e.theme
+}
+
+// GetThemeMode 获取主题模式, 是 ThemeMode_ 常量.
+func (e *Elementui) GetThemeMode() int {
+	return e.themeMode
+}
+
+// IsDark 判断当前是否使用的是暗色主题.
+func (e *Elementui) IsDark() bool {
+	return e.theme.IsDark
+}
@@ -1,36 +1,71 @@
 package eui
 
 import (
-	_ "embed"
 	"encoding/json"
 	"errors"
+	"sort"
 )
 
-var (
-	//go:embed res/fa-solid-900.ttf
-	fontAwesomeSolid []byte
-	//go:embed res/fa-brands-400.ttf
-	fontAwesomeBrands []byte
-	//go:embed res/fa-regular-400.ttf
-	fontAwesomeRegular []byte
-	//go:embed res/icons.min.json
-	fontAwesomeJson []byte
+// 更新 FontAwesome 时, 下载 fontawesome-free-x.x.x-web.zip 并解压, 把环境变量 FA_METADATA 设为其中的 metadata 目录, 再执行 go generate 重新生成 res/icons.min.json.
+//go:generate go run ./internal/genicons -metadata $FA_METADATA -out res/icons.min.json
 
+var (
 	// fontAwesomemMap 存放 FontAwesome 图标名称和 Unicode 码点
 	fontAwesomemMap map[string]int32
 )
 
 func init() {
+	// 使用 eui_subset 构建标签时没有内置数据, 由 LoadIconSubset 加载
+	if len(fontAwesomeJson) == 0 {
+		return
+	}
 	err := initFontAwesomeJson(fontAwesomeJson)
 	if err != nil {
 		panic(err)
 	}
 }
 
-// iconFa 是 FontAwesome 图标信息
+// LoadIconSubset 加载裁剪后的图标字体和图标数据, 替换掉内置的 FontAwesome 图标.
+//   - 一般不直接调用, 而是由 cmd/euisubset 生成的代码在 init 中调用, 然后使用 -tags eui_subset 构建, 这时 eui 不会内置完整的字体, 程序会小很多.
+//   - 必须在 NewElementui 之前调用, 之前 RegisterIconFont 注册的图标会被清除.
+//
+// fonts: 风格对应的字体数据, 键是'fa-solid', 'fa-regular', 'fa-brands', 没用到的风格可以不填.
+//
+// jsonData: 图标数据, 格式与 res/icons.min.json 相同.
+func LoadIconSubset(fonts map[string][]byte, jsonData []byte) error {
+	*Icons = IconCatalog{}
+	if err := initFontAwesomeJson(jsonData); err != nil {
+		return err
+	}
+	fontAwesomeSolid = fonts["fa-solid"]
+	fontAwesomeRegular = fonts["fa-regular"]
+	fontAwesomeBrands = fonts["fa-brands"]
+	return nil
+}
+
+// IconFontData 返回内置的 FontAwesome 字体数据, 使用 LoadIconSubset 加载过时返回加载的数据.
+//
+// style: 风格, 可为'fa-solid', 'fa-regular', 'fa-brands', 其它风格返回 nil.
+func IconFontData(style string) []byte {
+	switch style {
+	case "fa-solid":
+		return fontAwesomeSolid
+	case "fa-regular":
+		return fontAwesomeRegular
+	case "fa-brands":
+		return fontAwesomeBrands
+	}
+	return nil
+}
+
+// iconFa 是 FontAwesome 图标信息, 由 internal/genicons 根据 FontAwesome 的元数据生成.
 type iconFa struct {
-	Styles  []string `json:"s"`
-	Unicode int32    `json:"u"`
+	Aliases    []string `json:"a,omitempty"` // 别名, FontAwesome 5 的旧图标名
+	Categories []string `json:"c,omitempty"` // 分类
+	Label      string   `json:"l,omitempty"` // 显示名
+	Styles     []string `json:"s"`           // 免费的风格
+	Terms      []string `json:"t,omitempty"` // 搜索词
+	Unicode    int32    `json:"u"`           // Unicode 码点
 }
 
 // initFontAwesomeJson 把 FontAwesome icons 的 json 数据解析后存入 map 中.
@@ -44,11 +79,28 @@ func initFontAwesomeJson(jsonData []byte) error {
 		return errors.New("unmarshalling JSON failed: " + err.Error())
 	}
 	fontAwesomemMap = make(map[string]int32)
+	icons := make([]Icon, 0, len(iconsMap))
 	// 把 icon 风格和名字组合后放入 map
 	for name, icon := range iconsMap {
-		for _, style := range icon.Styles {
+		styles := make([]string, len(icon.Styles))
+		for i, style := range icon.Styles {
+			styles[i] = "fa-" + style
 			fontAwesomemMap["fa-"+style+" fa-"+name] = icon.Unicode
 		}
+		icons = append(icons, Icon{
+			Name:       name,
+			Prefix:     "fa-",
+			Styles:     styles,
+			Unicode:    icon.Unicode,
+			Label:      icon.Label,
+			Terms:      icon.Terms,
+			Aliases:    icon.Aliases,
+			Categories: icon.Categories,
+		})
 	}
+	sort.Slice(icons, func(i, j int) bool {
+		return icons[i].Name < icons[j].Name
+	})
+	Icons.add(icons)
 	return nil
 }
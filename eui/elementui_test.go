@@ -1,17 +1,23 @@
 package eui
 
 import (
-	"fmt"
-	"os"
 	"strings"
 	"testing"
 )
 
 func Test_initFontAwesomeJson(t *testing.T) {
-	// 打印 fontAwesomemMap 所有的图标名和Unicode码点
-	var sb strings.Builder
+	skipWithoutIconData(t)
+	if got := fontAwesomemMap["fa-solid fa-paw"]; got != 0xf1b0 {
+		t.Errorf("fa-solid fa-paw = %x, want f1b0", got)
+	}
+	if got := fontAwesomemMap["fa-brands fa-github"]; got != 0xf09b {
+		t.Errorf("fa-brands fa-github = %x, want f09b", got)
+	}
+	// 键是'风格 图标名', 每个图标都有码点
 	for k, v := range fontAwesomemMap {
-		sb.WriteString(fmt.Sprintf("%s, %d, %x\n", k, v, v))
+		style, name, ok := strings.Cut(k, " ")
+		if !ok || !strings.HasPrefix(style, "fa-") || !strings.HasPrefix(name, "fa-") || v == 0 {
+			t.Errorf("invalid entry %q: %x", k, v)
+		}
 	}
-	os.WriteFile("fontAwesomemMap6.6.0.txt", []byte(sb.String()), 0666)
 }
@@ -0,0 +1,228 @@
+//go:build windows
+
+package eui
+
+import (
+	"errors"
+
+	"github.com/twgh/xcgui/xc"
+	"github.com/twgh/xcgui/xcc"
+)
+
+// NewElementui 创建 Elementui 对象.
+//
+// fontSize: 字体大小. 一般使用 12.
+//
+// dpi: 窗口 dpi. 使用窗口.GetDPI()获取.
+//
+// theme: 亮色主题, 可不填, 不填时使用 DefaultTheme(). 暗色主题默认使用 DarkTheme(), 可调用 SetDarkTheme 修改.
+func NewElementui(fontSize, dpi int32, theme ...*Theme) *Elementui {
+	p := &Elementui{}
+	p.dpi = dpi
+	p.fontSize = fontSize
+	if len(theme) > 0 && theme[0] != nil {
+		p.lightTheme = theme[0]
+	} else {
+		p.lightTheme = DefaultTheme()
+	}
+	p.darkTheme = DarkTheme()
+	p.theme = p.lightTheme
+	p.hFontAwesomeMap = make(map[string]int)
+	// 使用 LoadIconSubset 加载裁剪后的字体时, 没用到的风格没有字体数据
+	for _, style := range []string{"fa-solid", "fa-brands", "fa-regular"} {
+		if data := IconFontData(style); len(data) > 0 {
+			p.hFontAwesomeMap[style] = xc.XFont_CreateFromMem(data, fontSize, xcc.FontStyle_Regular)
+		}
+	}
+	xc.XC_SetTextRenderingHint(xcc.TextRenderingHintAntiAliasGridFit)
+	return p
+}
+
+// SetTheme 设置当前使用的主题, 本对象创建的所有元素都会使用新主题重绘, 不需要重新创建元素.
+//   - 之后再调用 SetThemeMode 或 SetSystemDark 时, 会被替换成亮色或暗色主题.
+//
+// theme: 主题.
+func (e *Elementui) SetTheme(theme *Theme) *Elementui {
+	if theme == nil {
+		return e
+	}
+	e.theme = theme
+	for hEle, st := range stateMap {
+		if st.base().eui == e {
+			applyTheme(hEle)
+		}
+	}
+	return e
+}
+
+// SetLightTheme 设置亮色主题. 当前是亮色模式时会立即应用.
+//
+// theme: 主题.
+func (e *Elementui) SetLightTheme(theme *Theme) *Elementui {
+	if theme != nil {
+		e.lightTheme = theme
+		e.updateThemeMode()
+	}
+	return e
+}
+
+// SetDarkTheme 设置暗色主题. 当前是暗色模式时会立即应用.
+//
+// theme: 主题.
+func (e *Elementui) SetDarkTheme(theme *Theme) *Elementui {
+	if theme != nil {
+		e.darkTheme = theme
+		e.updateThemeMode()
+	}
+	return e
+}
+
+// SetThemeMode 设置主题模式, 会立即切换到对应的主题.
+//
+// mode: 主题模式, 可使用常量: ThemeMode_.
+//   - 0 = 亮色
+//   - 1 = 暗色
+//   - 2 = 跟随系统, 需要调用 SetSystemDark 告知系统是否为暗色
+func (e *Elementui) SetThemeMode(mode int) *Elementui {
+	if mode < ThemeMode_Light || mode > ThemeMode_System {
+		mode = ThemeMode_Light
+	}
+	e.themeMode = mode
+	e.updateThemeMode()
+	return e
+}
+
+// SetSystemDark 告知系统当前是否为暗色. 主题模式为 ThemeMode_System 时会立即切换到对应的主题.
+//   - 可在程序启动时和收到系统主题改变的消息时调用.
+//
+// isDark: 系统是否为暗色.
+func (e *Elementui) SetSystemDark(isDark bool) *Elementui {
+	e.systemDark = isDark
+	if e.themeMode == ThemeMode_System {
+		e.updateThemeMode()
+	}
+	return e
+}
+
+// RegisterIconFont 注册额外的图标字体, 如 FontAwesome Pro 或自己的图标字体. 注册后可以像内置图标一样使用, 如 SetIconName("acme-solid acme-logo").
+//   - 图标会加到共用的图标目录 Icons 中, 字体只属于本 Elementui 对象, 其它 Elementui 对象要使用这些图标也需注册.
+//   - 同一个风格可以多次注册, 后注册的字体会替换掉之前的, 图标会合并.
+//
+// style: 风格, 如'acme-solid'. 第一个'-'及前面的部分是图标名前缀, 如'acme-'.
+//
+// ttf: 字体文件数据.
+//
+// icons: 图标名对应的 Unicode 码点, 图标名可带或不带前缀, 如'acme-logo'或'logo'.
+func (e *Elementui) RegisterIconFont(style string, ttf []byte, icons map[string]int32) error {
+	if err := checkIconStyle(style); err != nil {
+		return err
+	}
+	if len(ttf) == 0 {
+		return errors.New("empty icon font data")
+	}
+	// 先注册图标名再创建字体, 注册失败时不会留下没人释放的字体
+	if err := Icons.Register(style, icons); err != nil {
+		return err
+	}
+	hFont := xc.XFont_CreateFromMem(ttf, e.fontSize, xcc.FontStyle_Regular)
+	if hFont == 0 {
+		return errors.New("create icon font failed: " + style)
+	}
+	e.hFontAwesomeMap[style] = hFont
+	if e.iconFontData == nil {
+		e.iconFontData = make(map[string][]byte)
+	}
+	e.iconFontData[style] = ttf
+	// 之前按其它大小创建的字体是旧字体的, 需重新创建
+	for key := range e.iconFonts {
+		if key.style == style {
+			delete(e.iconFonts, key)
+		}
+	}
+	return nil
+}
+
+// getIconFont 返回指定风格和大小的图标字体句柄, 没有这个风格时返回 0.
+//   - 大小与 NewElementui 的 fontSize 相同时使用已有的字体, 其它大小的字体第一次使用时创建, 之后由所有元素共用.
+//
+// style: 风格, 如'fa-solid'.
+//
+// size: 字体大小, 小于 1 时使用 fontSize.
+func (e *Elementui) getIconFont(style string, size int32) int {
+	if size < 1 || size == e.fontSize {
+		return e.hFontAwesomeMap[style]
+	}
+	key := iconFontKey{style: style, size: size}
+	if hFont, ok := e.iconFonts[key]; ok {
+		return hFont
+	}
+	ttf := e.iconFontData[style]
+	if ttf == nil {
+		ttf = IconFontData(style)
+	}
+	if len(ttf) == 0 {
+		return 0
+	}
+	hFont := xc.XFont_CreateFromMem(ttf, size, xcc.FontStyle_Regular)
+	if e.iconFonts == nil {
+		e.iconFonts = make(map[iconFontKey]int)
+	}
+	e.iconFonts[key] = hFont
+	return hFont
+}
+
+// IconSvg 创建图标的炫彩 svg, 失败返回 0. 可用于 xcgui 的列表, 菜单, 窗口标题等, 也可以传给 eui 元素的 SetHSvg.
+//   - 不再使用时需调用 xc.XSvg_Destroy 销毁.
+//   - svg 文本的规则见 IconSvgText.
+//
+// name: 图标名, 可以是'fa-solid fa-paw', 'fa-paw' 或 'paw'.
+//
+// size: 图标大小, 单位是像素.
+//
+// color: 图标颜色, xc.RGBA 的返回值.
+func (e *Elementui) IconSvg(name string, size int32, color uint32) int {
+	text, err := e.IconSvgText(name, size, color)
+	if err != nil {
+		return 0
+	}
+	return xc.XSvg_LoadStringW(text)
+}
+
+// IconImage 创建图标的炫彩图片, 失败返回 0. 可用于 xcgui 的列表, 菜单, 托盘图标, 窗口图标等, 也可以传给 eui 元素的 SetHImage.
+//   - 图片由 svg 创建, 缩放后也是清晰的.
+//   - svg 文本的规则见 IconSvgText.
+//
+// name: 图标名, 可以是'fa-solid fa-paw', 'fa-paw' 或 'paw'.
+//
+// size: 图标大小, 单位是像素.
+//
+// color: 图标颜色, xc.RGBA 的返回值.
+func (e *Elementui) IconImage(name string, size int32, color uint32) int {
+	text, err := e.IconSvgText(name, size, color)
+	if err != nil {
+		return 0
+	}
+	return xc.XImage_LoadSvgStringW(text)
+}
+
+// 根据主题模式切换主题.
+func (e *Elementui) updateThemeMode() {
+	theme := e.lightTheme
+	if e.themeMode == ThemeMode_Dark || e.themeMode == ThemeMode_System && e.systemDark {
+		theme = e.darkTheme
+	}
+	if theme != e.theme {
+		e.SetTheme(theme)
+	}
+}
+
+// 记录元素的状态和元素所属的 Elementui 对象. 元素销毁时需调用 onDestroyEle 删除记录.
+//
+// o: 元素对象基类.
+//
+// s: 元素状态.
+func (e *Elementui) addEle(o *objBase, s stater) {
+	o.eui = e
+	s.base().eui = e
+	setState(o.H, s)
+}
@@ -1,23 +1,76 @@
+//go:build windows
+
 package eui
 
 import (
 	"github.com/twgh/xcgui/xc"
+	"github.com/twgh/xcgui/xcc"
 )
 
-// funcDrawEleMap 存放元素绘制事件
-var funcDrawEleMap = map[string]xc.XE_PAINT1{
-	"onDrawButton_Default":     onDrawButton_Default,
-	"onDrawButton_Color":       onDrawButton_Color,
-	"onDrawButton_Text":        onDrawButton_Text,
-	"onDrawButton_Color_Plain": onDrawButton_Color_Plain,
-	"onDrawEdit":               onDrawEdit,
-}
-
-// onDrawEle 元素绘制事件
+// onDrawEle 元素绘制事件, 收集元素的文本和状态放到 PaintContext 中, 再根据元素的绘制函数名调用绘制函数.
 func onDrawEle(hEle int, hDraw int, pbHandled *bool) int {
-	funcDrawEle := xc.XC_GetProperty(hEle, "element-func-draw-ele")
-	if f, ok := funcDrawEleMap[funcDrawEle]; ok {
-		f(hEle, hDraw, pbHandled)
+	st, ok := stateMap[hEle]
+	if !ok {
+		return 0
+	}
+	painter, ok := painterMap[st.base().painter]
+	if !ok {
+		return 0
+	}
+	ctx := &PaintContext{
+		HEle:    hEle,
+		HDraw:   hDraw,
+		Canvas:  NewDrawCanvas(hDraw),
+		Width:   xc.XEle_GetWidth(hEle),
+		Height:  xc.XEle_GetHeight(hEle),
+		Theme:   getTheme(hEle),
+		Focus:   xc.XEle_IsFocus(hEle),
+		Enable:  xc.XEle_IsEnable(hEle),
+		Handled: true,
+		state:   st,
+	}
+	if xc.XC_GetObjectType(hEle) == xcc.XC_BUTTON {
+		ctx.Text = xc.XBtn_GetText(hEle)
+		ctx.State = int(xc.XBtn_GetStateEx(hEle))
+	} else if s, ok := st.(*editState); ok {
+		s.hasText = xc.XEdit_GetLength(hEle) > 0
+	}
+	painter(ctx)
+	if ctx.TextColor != 0 {
+		xc.XEle_SetTextColor(hEle, ctx.TextColor)
 	}
+	*pbHandled = ctx.Handled
 	return 0
 }
+
+// onDestroyEle 元素销毁事件, 删除元素的状态.
+func onDestroyEle(hEle int, pbHandled *bool) int {
+	deleteState(hEle)
+	return 0
+}
+
+// applyTheme 把元素所属 Elementui 的当前主题重新应用到元素上, 然后重绘.
+func applyTheme(hEle int) {
+	switch s := stateMap[hEle].(type) {
+	case *buttonState:
+		// 编辑框的前置/后置文字绘制时才取主题颜色, 重绘即可
+		if s.painter == "onDrawEditSlot" {
+			break
+		}
+		btn := &Button{}
+		btn.SetHandle(hEle)
+		btn.H = hEle
+		btn.eui = s.eui
+		btn.SetStyle(s.style)
+	case *editState:
+		theme := s.eui.theme
+		xc.XEdit_SetDefaultTextColor(hEle, theme.ColorTextPlaceholder)
+		xc.XEdit_SetCaretColor(hEle, theme.ColorTextRegular)
+		xc.XEle_SetTextColor(hEle, theme.ColorTextRegular)
+	case *iconPickerState:
+		// 绘制时才取主题颜色, 重绘即可
+	default:
+		return
+	}
+	xc.XEle_Redraw(hEle, false)
+}
@@ -0,0 +1,643 @@
+package eui
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 使用 go test -run Test_Golden -update 重新生成 testdata/golden 中的图片.
+var update = flag.Bool("update", false, "重新生成 testdata/golden 中的图片")
+
+// golden 图片比较时允许的误差.
+const (
+	goldenTolerance = 2 // 每个颜色通道允许的差值
+	goldenMaxDiff   = 0 // 允许超出误差的像素数
+)
+
+// 测试用的炫彩句柄, 会登记到 ImageCanvas 中.
+const (
+	testHSvg   = 1
+	testHImage = 2
+	testHFont  = 3
+	testHFontL = 4 // 大图标字体
+)
+
+// 测试用的图标来源.
+const (
+	testIcon_None = iota
+	testIcon_Svg
+	testIcon_Image
+	testIcon_Fa
+)
+
+// newTestCanvas 创建登记了测试用 svg, 图片和字体的画布.
+func newTestCanvas(width, height int32) *ImageCanvas {
+	img := image.NewRGBA(image.Rect(0, 0, 14, 14))
+	for y := 0; y < 14; y++ {
+		for x := 0; x < 14; x++ {
+			c := color.RGBA{R: 230, G: 162, B: 60, A: 255}
+			if (x/7+y/7)%2 == 0 {
+				c = color.RGBA{R: 103, G: 194, B: 58, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return NewImageCanvas(width, height).
+		AddSvg(testHSvg, 16, 16).
+		AddImage(testHImage, img).
+		AddFont(testHFont, 14).
+		AddFont(testHFontL, 24)
+}
+
+// setTestIcon 给元素设置测试用的图标.
+func setTestIcon(s *eleState, cv Canvas, icon int) {
+	switch icon {
+	case testIcon_Svg:
+		s.hSvg = testHSvg
+	case testIcon_Image:
+		s.hImage = testHImage
+	case testIcon_Fa:
+		s.iconFa = "\uf1b0" // fa-paw
+		s.hFontAwesome = testHFont
+		size := cv.TextSize(s.iconFa, testHFont)
+		s.iconFaCx, s.iconFaCy = size.CX, size.CY
+	}
+}
+
+// paintTest 用绘制函数把元素画到新的画布上.
+func paintTest(st stater, width, height int32, icon int, setup func(ctx *PaintContext)) image.Image {
+	cv := newTestCanvas(width, height)
+	setTestIcon(st.base(), cv, icon)
+	ctx := &PaintContext{
+		Canvas:  cv,
+		Width:   width,
+		Height:  height,
+		Theme:   defaultTheme,
+		Enable:  true,
+		Handled: true,
+		state:   st,
+	}
+	if setup != nil {
+		setup(ctx)
+	}
+	painterMap[st.base().painter](ctx)
+	return cv.Image()
+}
+
+// sheet 把多张图片按表格拼到一起, 每个格子大小相同.
+type sheet struct {
+	img        *image.RGBA
+	cellWidth  int
+	cellHeight int
+}
+
+func newSheet(cols, rows, cellWidth, cellHeight int) *sheet {
+	return &sheet{
+		img:        image.NewRGBA(image.Rect(0, 0, cols*cellWidth, rows*cellHeight)),
+		cellWidth:  cellWidth,
+		cellHeight: cellHeight,
+	}
+}
+
+func (s *sheet) put(col, row int, img image.Image) {
+	pt := image.Pt(col*s.cellWidth+2, row*s.cellHeight+2)
+	draw.Draw(s.img, img.Bounds().Add(pt), img, image.Point{}, draw.Over)
+}
+
+// 按钮尺寸对应的宽高, 与 Button.SetSizeEle 相同.
+var testButtonSizes = []Size{{98, 40}, {98, 36}, {80, 32}, {80, 28}}
+
+func Test_Golden_Button(t *testing.T) {
+	// 列: 纯文本, 图标+文字, 只有图标
+	type column struct {
+		icon int
+		text string
+	}
+	columns := []column{
+		{testIcon_None, "按钮"},
+		{testIcon_Svg, "按钮"}, {testIcon_Image, "按钮"}, {testIcon_Fa, "按钮"},
+		{testIcon_Svg, ""}, {testIcon_Image, ""}, {testIcon_Fa, ""},
+	}
+	for style := ButtonStyle_Default; style <= ButtonStyle_Text; style++ {
+		// 行: 尺寸 × 朴素 × 圆形
+		sh := newSheet(len(columns), len(testButtonSizes)*4, 102, 44)
+		row := 0
+		for _, size := range testButtonSizes {
+			for _, plain := range []bool{false, true} {
+				for _, circle := range []bool{false, true} {
+					for col, c := range columns {
+						s := &buttonState{plain: plain, circle: circle}
+						s.round = defaultTheme.BorderRadiusBase
+						s.setStyle(defaultTheme, style)
+						img := paintTest(s, size.CX, size.CY, c.icon, func(ctx *PaintContext) {
+							ctx.Text = c.text
+						})
+						sh.put(col, row, img)
+					}
+					row++
+				}
+			}
+		}
+		checkGolden(t, fmt.Sprintf("button_style%d", style), sh.img)
+	}
+}
+
+func Test_Golden_ButtonState(t *testing.T) {
+	// 列: 按钮状态, 行: 样式 × 朴素
+	states := []int{ButtonState_Leave, ButtonState_Stay, ButtonState_Down, ButtonState_Disable}
+	sh := newSheet(len(states), (ButtonStyle_Text+1)*2, 102, 44)
+	row := 0
+	for style := ButtonStyle_Default; style <= ButtonStyle_Text; style++ {
+		for _, plain := range []bool{false, true} {
+			for col, state := range states {
+				s := &buttonState{plain: plain}
+				s.round = defaultTheme.BorderRadiusBase
+				s.setStyle(defaultTheme, style)
+				img := paintTest(s, 98, 40, testIcon_Fa, func(ctx *PaintContext) {
+					ctx.Text = "按钮"
+					ctx.State = state
+					ctx.Enable = state != ButtonState_Disable
+				})
+				sh.put(col, row, img)
+			}
+			row++
+		}
+	}
+	checkGolden(t, "button_state", sh.img)
+}
+
+func Test_Golden_ButtonIconPosition(t *testing.T) {
+	// 列: 图标位置, 行: 图标来源
+	positions := []int{ButtonIconPosition_Left, ButtonIconPosition_Right, ButtonIconPosition_Top}
+	sh := newSheet(len(positions), 3, 102, 68)
+	for row, icon := range []int{testIcon_Svg, testIcon_Image, testIcon_Fa} {
+		for col, position := range positions {
+			s := &buttonState{iconPosition: position, iconGap: 6}
+			s.round = defaultTheme.BorderRadiusBase
+			s.setStyle(defaultTheme, ButtonStyle_Primary)
+			sh.put(col, row, paintTest(s, 98, 64, icon, func(ctx *PaintContext) {
+				ctx.Text = "上传"
+			}))
+		}
+	}
+	checkGolden(t, "button_icon_position", sh.img)
+}
+
+func Test_Golden_ButtonGroup(t *testing.T) {
+	// 行: 默认, 朴素彩色鼠标停留在第一个按钮上, 彩色, 竖排默认
+	type row struct {
+		style    int
+		plain    bool
+		stay     bool
+		vertical bool
+	}
+	rows := []row{
+		{ButtonStyle_Default, false, false, false},
+		{ButtonStyle_Primary, true, true, false},
+		{ButtonStyle_Primary, false, false, false},
+		{ButtonStyle_Default, false, false, true},
+	}
+	texts := []string{"上一页", "1", "下一页"}
+	sizes := []Size{{80, 32}, {40, 32}, {80, 32}}
+	img := image.NewRGBA(image.Rect(0, 0, 210, 3*36+110))
+	top := 2
+	for _, r := range rows {
+		rects, sides, total := layoutButtonGroup(sizes, r.vertical)
+		// 鼠标停留的按钮在最上面, 最后画
+		order := []int{1, 2, 0}
+		if !r.stay {
+			order = []int{0, 1, 2}
+		}
+		for _, i := range order {
+			rc := rects[i]
+			s := &buttonState{plain: r.plain, joinSides: sides[i]}
+			s.round = defaultTheme.BorderRadiusBase
+			s.setStyle(defaultTheme, r.style)
+			btn := paintTest(s, rc.Width(), rc.Height(), testIcon_None, func(ctx *PaintContext) {
+				ctx.Text = texts[i]
+				if r.stay && i == 0 {
+					ctx.State = ButtonState_Stay
+				}
+			})
+			pt := image.Pt(2+int(rc.Left), top+int(rc.Top))
+			draw.Draw(img, btn.Bounds().Add(pt), btn, image.Point{}, draw.Over)
+		}
+		top += int(total.CY) + 4
+	}
+	checkGolden(t, "button_group", img)
+}
+
+func Test_Golden_ButtonText(t *testing.T) {
+	// 列: 太长不换行, 自动换行, '\n'换行, 顶部图标+两行文字
+	type column struct {
+		text     string
+		wrap     bool
+		position int
+		height   int32
+	}
+	columns := []column{
+		{text: "很长很长的按钮文字"},
+		{text: "很长很长的按钮文字", wrap: true},
+		{text: "第一行\n第二行"},
+		{text: "上传文件到服务器", wrap: true, position: ButtonIconPosition_Top, height: 64},
+	}
+	sh := newSheet(len(columns), 1, 102, 68)
+	for col, c := range columns {
+		s := &buttonState{textWrap: c.wrap, iconPosition: c.position}
+		s.round = defaultTheme.BorderRadiusBase
+		s.setStyle(defaultTheme, ButtonStyle_Default)
+		height, icon := int32(40), testIcon_None
+		if c.height > 0 {
+			height, icon = c.height, testIcon_Fa
+		}
+		sh.put(col, 0, paintTest(s, 98, height, icon, func(ctx *PaintContext) {
+			ctx.Text = c.text
+		}))
+	}
+	checkGolden(t, "button_text", sh.img)
+}
+
+func Test_Golden_Edit(t *testing.T) {
+	// 列: 图标来源 × 图标位置 × 图标颜色自动改变
+	type column struct {
+		icon      int
+		right     bool
+		autoColor bool
+	}
+	var columns []column
+	columns = append(columns, column{icon: testIcon_None})
+	for _, icon := range []int{testIcon_Svg, testIcon_Image, testIcon_Fa} {
+		for _, right := range []bool{false, true} {
+			columns = append(columns, column{icon: icon, right: right})
+		}
+		columns = append(columns, column{icon: icon, autoColor: true})
+	}
+	// 行: 尺寸 × 状态
+	type state struct {
+		mouseStay, focus, disable bool
+	}
+	states := []state{{}, {mouseStay: true}, {focus: true}, {disable: true}}
+	heights := []int32{40, 36, 32, 28}
+	sh := newSheet(len(columns), len(heights)*len(states), 184, 44)
+	row := 0
+	for _, height := range heights {
+		for _, st := range states {
+			for col, c := range columns {
+				s := &editState{iconRight: c.right, autoColor: c.autoColor}
+				s.painter = "onDrawEdit"
+				s.round = defaultTheme.BorderRadiusBase
+				s.spaceLeft = defaultTheme.SpaceInputIcon
+				s.mouseStay = st.mouseStay
+				img := paintTest(s, 180, height, c.icon, func(ctx *PaintContext) {
+					ctx.Focus = st.focus
+					ctx.Enable = !st.disable
+				})
+				sh.put(col, row, img)
+			}
+			row++
+		}
+	}
+	checkGolden(t, "edit", sh.img)
+
+	// 直角编辑框, 图标和边框的间距使用 spaceLeft
+	s := &editState{}
+	s.painter = "onDrawEdit"
+	s.spaceLeft = defaultTheme.SpaceInputIcon
+	checkGolden(t, "edit_square", paintTest(s, 180, 40, testIcon_Fa, nil))
+}
+
+// newTestClearIcon 返回使用测试字体的清空图标.
+func newTestClearIcon(cv Canvas) faIcon {
+	icon := faIcon{text: "\uf057", hFont: testHFont} // fa-circle-xmark
+	size := cv.TextSize(icon.text, testHFont)
+	icon.cx, icon.cy = size.CX, size.CY
+	return icon
+}
+
+func Test_Golden_EditClearable(t *testing.T) {
+	// 列: 无图标, 右边图标; 行: 有内容拥有焦点, 鼠标停留在清空图标上, 没有内容, 有内容但没有焦点
+	type state struct {
+		hasText, focus, mouseStay, hover bool
+	}
+	states := []state{{true, true, false, false}, {true, false, true, true}, {false, true, false, false}, {true, false, false, false}}
+	sh := newSheet(2, len(states), 184, 44)
+	for row, st := range states {
+		for col, right := range []bool{false, true} {
+			s := &editState{iconRight: right, clearable: true, hasText: st.hasText}
+			s.painter = "onDrawEdit"
+			s.round = defaultTheme.BorderRadiusBase
+			s.spaceLeft = defaultTheme.SpaceInputIcon
+			s.mouseStay = st.mouseStay
+			if st.hover {
+				s.toolHover = editTool_Clear
+			}
+			icon := testIcon_None
+			if right {
+				icon = testIcon_Fa
+			}
+			sh.put(col, row, paintTest(s, 180, 40, icon, func(ctx *PaintContext) {
+				s.clearIcon = newTestClearIcon(ctx.Canvas)
+				ctx.Focus = st.focus
+			}))
+		}
+	}
+	checkGolden(t, "edit_clearable", sh.img)
+}
+
+func Test_Golden_EditPassword(t *testing.T) {
+	// 列: 左边图标, 拥有焦点, 显示密码, 同时可清空
+	type column struct {
+		focus, visible, clearable bool
+	}
+	columns := []column{{}, {focus: true}, {focus: true, visible: true}, {focus: true, clearable: true}}
+	sh := newSheet(len(columns), 1, 184, 44)
+	for col, c := range columns {
+		s := &editState{showPassword: true, passwordVisible: c.visible, clearable: c.clearable, hasText: true}
+		s.painter = "onDrawEdit"
+		s.round = defaultTheme.BorderRadiusBase
+		s.spaceLeft = defaultTheme.SpaceInputIcon
+		sh.put(col, 0, paintTest(s, 180, 40, testIcon_Fa, func(ctx *PaintContext) {
+			s.clearIcon = newTestClearIcon(ctx.Canvas)
+			show := faIcon{text: "\uf06e", hFont: testHFont} // fa-eye
+			hide := faIcon{text: "\uf070", hFont: testHFont} // fa-eye-slash
+			size := ctx.Canvas.TextSize(show.text, testHFont)
+			show.cx, show.cy = size.CX, size.CY
+			hide.cx, hide.cy = size.CX, size.CY
+			s.passwordIcons = [2]faIcon{show, hide}
+			ctx.Focus = c.focus
+		}))
+	}
+	checkGolden(t, "edit_password", sh.img)
+}
+
+func Test_Golden_EditSlot(t *testing.T) {
+	// 行: 前置文字, 后置文字+拥有焦点, 前置图标+后置按钮并且鼠标停留在按钮上
+	type slot struct {
+		text   string
+		icon   int
+		button bool
+	}
+	type row struct {
+		prepend, append slot
+		focus, stay     bool
+	}
+	rows := []row{
+		{prepend: slot{text: "http://"}},
+		{append: slot{text: ".com"}, focus: true},
+		{prepend: slot{icon: testIcon_Fa}, append: slot{text: "搜索", button: true}, stay: true},
+	}
+	const width, height = 300, 40
+	img := image.NewRGBA(image.Rect(0, 0, width+4, len(rows)*(height+4)))
+	for y, r := range rows {
+		s := &editState{slotStay: r.stay}
+		s.painter = "onDrawEdit"
+		s.round = defaultTheme.BorderRadiusBase
+		s.spaceLeft = defaultTheme.SpaceInputIcon
+		slots := [2]slot{r.prepend, r.append}
+		sides := [2]int{side_Right, side_Left}
+		states := [2]*buttonState{}
+		cv := newTestCanvas(1, 1)
+		for i, sl := range slots {
+			if sl.text == "" && sl.icon == testIcon_None {
+				continue
+			}
+			bs := &buttonState{joinSides: sides[i]}
+			bs.round = defaultTheme.BorderRadiusBase
+			if sl.button {
+				bs.setStyle(defaultTheme, ButtonStyle_Default)
+				s.slots[i] = editSlot{cx: 80, ownBorder: true}
+			} else {
+				bs.painter = "onDrawEditSlot"
+				setTestIcon(&bs.eleState, cv, sl.icon)
+				var icon Size
+				if sl.icon != testIcon_None {
+					icon = Size{CX: bs.iconFaCx, CY: bs.iconFaCy}
+				}
+				var text Size
+				if sl.text != "" {
+					text = cv.TextSize(sl.text, 0)
+				}
+				s.slots[i] = editSlot{cx: editSlotWidth(icon, text, defaultTheme.SpaceIconText, defaultTheme.PaddingInputSlot)}
+			}
+			states[i] = bs
+		}
+
+		top := 2 + y*(height+4)
+		put := func(src image.Image, rc Rect) {
+			pt := image.Pt(2+int(rc.Left), top+int(rc.Top))
+			draw.Draw(img, src.Bounds().Add(pt), src, image.Point{}, draw.Over)
+		}
+		put(paintTest(s, width, height, testIcon_None, func(ctx *PaintContext) {
+			ctx.Focus = r.focus
+		}), Rect{})
+		var rcs [2]Rect
+		rcs[editSlot_Prepend], rcs[editSlot_Append] = s.slotRects(width, height)
+		for i, bs := range states {
+			if bs == nil {
+				continue
+			}
+			put(paintTest(bs, rcs[i].Width(), rcs[i].Height(), slots[i].icon, func(ctx *PaintContext) {
+				ctx.Text = slots[i].text
+				if slots[i].button && r.stay {
+					ctx.State = ButtonState_Stay
+				}
+			}), rcs[i])
+		}
+	}
+	checkGolden(t, "edit_slot", img)
+}
+
+func Test_Golden_Textarea(t *testing.T) {
+	// 列: 普通, 拥有焦点并可调整高度, 禁用, 滚动条滑块(离开, 停留)
+	sh := newSheet(4, 1, 184, 84)
+	for col, c := range []struct {
+		focus, enable bool
+		grip          int32
+	}{{enable: true}, {focus: true, enable: true, grip: textareaGripSize}, {grip: textareaGripSize}} {
+		s := &editState{gripSize: c.grip}
+		s.painter = "onDrawTextarea"
+		s.round = defaultTheme.BorderRadiusBase
+		sh.put(col, 0, paintTest(s, 180, 80, testIcon_None, func(ctx *PaintContext) {
+			ctx.Focus = c.focus
+			ctx.Enable = c.enable
+		}))
+	}
+
+	thumbs := newSheet(2, 1, 12, 40)
+	for col, state := range []int{ButtonState_Leave, ButtonState_Stay} {
+		s := &eleState{painter: "onDrawScrollThumb"}
+		thumbs.put(col, 0, paintTest(s, scrollBarSize, 36, testIcon_None, func(ctx *PaintContext) {
+			ctx.State = state
+		}))
+	}
+	sh.put(3, 0, thumbs.img)
+	checkGolden(t, "textarea", sh.img)
+}
+
+func Test_Golden_Autocomplete(t *testing.T) {
+	s := &autocompleteState{itemHeight: 34, padding: 6, scrollBar: scrollBarSize, maxRows: 4}
+	s.painter = "onDrawAutocomplete"
+	s.round = defaultTheme.BorderRadiusBase
+	s.setItems("ab", []Suggestion{{Value: "abc"}, {Value: "xAByz"}, {Value: "no match"}, {Value: "ab 很长很长很长很长很长很长的建议"}, {Value: "last"}})
+	height := s.popupHeight()
+	sh := newSheet(3, 1, 184, int(height)+4)
+
+	// 第二条被选中
+	s.moveHover(1, height)
+	s.moveHover(1, height)
+	sh.put(0, 0, paintTest(s, 180, height, testIcon_None, nil))
+
+	// 选中最后一条, 滚动到底部
+	s.moveHover(3, height)
+	sh.put(1, 0, paintTest(s, 180, height, testIcon_None, nil))
+
+	// 自定义绘制函数, 文字右对齐
+	s.itemPainter = func(ctx *SuggestionPaintContext) {
+		ctx.Canvas.SetBrushColor(ctx.Theme.ColorTextSecondary)
+		ctx.Canvas.SetTextAlign(TextAlign_VCenter | TextAlign_NoWrap | TextAlign_Right)
+		rc := ctx.Rect
+		rc.Right -= 10
+		ctx.Canvas.DrawText(ctx.Item.Value, rc)
+	}
+	s.setItems("", s.items[:2])
+	sh.put(2, 0, paintTest(s, 180, s.popupHeight(), testIcon_None, nil))
+	checkGolden(t, "autocomplete", sh.img)
+}
+
+func Test_Golden_IconPicker(t *testing.T) {
+	s := newTestPicker()
+	s.cellWidth, s.cellHeight = 56, 48
+	s.painter = "onDrawIconPicker"
+	s.round = defaultTheme.BorderRadiusBase
+	s.fonts = map[string]int{"fa-solid": testHFont, "fa-regular": testHFont}
+	s.filter(newTestPickerCatalog())
+	s.selected = "fa-ab"
+	s.hover = 2
+	sh := newSheet(3, 1, 244, 164)
+	sh.put(0, 0, paintTest(s, 240, 160, testIcon_None, nil))
+
+	// 滚动到中间, 第一行只显示一部分
+	s.scrollY = 70
+	sh.put(1, 0, paintTest(s, 240, 160, testIcon_None, func(ctx *PaintContext) {
+		ctx.Focus = true
+	}))
+
+	// 没有图标
+	s.query = "none"
+	s.filter(newTestPickerCatalog())
+	sh.put(2, 0, paintTest(s, 240, 160, testIcon_None, nil))
+	checkGolden(t, "iconpicker", sh.img)
+}
+
+func Test_Golden_IconColor(t *testing.T) {
+	// 列: 默认按钮, 彩色按钮, 编辑框, 大图标编辑框, 禁用的编辑框. 行: svg, Font Awesome
+	red := rgba(245, 108, 108, 255)
+	sh := newSheet(5, 2, 184, 44)
+	for row, icon := range []int{testIcon_Svg, testIcon_Fa} {
+		for col, style := range []int{ButtonStyle_Default, ButtonStyle_Primary} {
+			s := &buttonState{}
+			s.round = defaultTheme.BorderRadiusBase
+			s.iconColor = red
+			s.setStyle(defaultTheme, style)
+			sh.put(col, row, paintTest(s, 98, 40, icon, func(ctx *PaintContext) {
+				ctx.Text = "按钮"
+			}))
+		}
+		for col := 2; col < 5; col++ {
+			s := &editState{}
+			s.painter = "onDrawEdit"
+			s.round = defaultTheme.BorderRadiusBase
+			s.iconColor = red
+			img := paintTest(s, 180, 40, icon, func(ctx *PaintContext) {
+				if col == 3 && icon == testIcon_Fa {
+					s.hFontAwesome = testHFontL
+					size := ctx.Canvas.TextSize(s.iconFa, testHFontL)
+					s.iconFaCx, s.iconFaCy = size.CX, size.CY
+				}
+				ctx.Enable = col != 4
+			})
+			sh.put(col, row, img)
+		}
+	}
+	checkGolden(t, "icon_color", sh.img)
+}
+
+// checkGolden 把图片与 testdata/golden 中同名的 png 比较, 使用 -update 时改为保存图片.
+func checkGolden(t *testing.T, name string, img image.Image) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".png")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := writePng(path, img); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("%v, 使用 -update 生成", err)
+	}
+	defer f.Close()
+	want, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := diffImage(want, img, goldenTolerance); n > goldenMaxDiff {
+		actual := filepath.Join(os.TempDir(), "eui-golden", name+".png")
+		_ = os.MkdirAll(filepath.Dir(actual), 0755)
+		if err := writePng(actual, img); err != nil {
+			t.Errorf("%s: %d 个像素不同, 保存实际绘制结果失败: %v", name, n, err)
+			return
+		}
+		t.Errorf("%s: %d 个像素不同, 实际绘制结果: %s", name, n, actual)
+	}
+}
+
+// diffImage 返回两张图片中颜色差值超过 tolerance 的像素数, 大小不同时返回全部像素数.
+func diffImage(a, b image.Image, tolerance int) int {
+	if a.Bounds().Size() != b.Bounds().Size() {
+		return a.Bounds().Dx()*a.Bounds().Dy() + 1
+	}
+	n := 0
+	ab, bb := a.Bounds(), b.Bounds()
+	for y := 0; y < ab.Dy(); y++ {
+		for x := 0; x < ab.Dx(); x++ {
+			c1 := color.NRGBAModel.Convert(a.At(ab.Min.X+x, ab.Min.Y+y)).(color.NRGBA)
+			c2 := color.NRGBAModel.Convert(b.At(bb.Min.X+x, bb.Min.Y+y)).(color.NRGBA)
+			if absDiff(c1.R, c2.R) > tolerance || absDiff(c1.G, c2.G) > tolerance ||
+				absDiff(c1.B, c2.B) > tolerance || absDiff(c1.A, c2.A) > tolerance {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func absDiff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
+
+func writePng(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
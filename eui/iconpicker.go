@@ -0,0 +1,299 @@
+//go:build windows
+
+package eui
+
+import (
+	"github.com/twgh/xcgui/widget"
+	"github.com/twgh/xcgui/xc"
+)
+
+// IconPicker 是图标选择器, 以格子的形式显示图标目录 Icons 中的图标, 可搜索, 按风格和分类筛选, 点击选中图标. 继承 widget.Element.
+//   - 只绘制可见的几行图标, 显示全部 2000+ 图标也不会卡.
+type IconPicker struct {
+	widget.Element
+	objBase
+}
+
+// CreateIconPicker 创建图标选择器.
+//   - 内部注册了元素绘制事件, 鼠标移动/离开事件, 鼠标左键弹起事件, 鼠标滚轮事件, 元素销毁事件.
+//
+// hParent: 父元素或父窗口句柄.
+//
+// opts: IconPickerOption 图标选择器选项, 可不填.
+func (e *Elementui) CreateIconPicker(hParent int, opts ...IconPickerOption) *IconPicker {
+	var opt IconPickerOption
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Width < 1 {
+		opt.Width = 480
+	}
+	if opt.Height < 1 {
+		opt.Height = 320
+	}
+	if opt.CellWidth < 1 {
+		opt.CellWidth = iconPickerCellWidth
+	}
+	if opt.CellHeight < 1 {
+		opt.CellHeight = iconPickerCellHeight
+	}
+
+	// 创建元素对象
+	p := &IconPicker{}
+	p.hFontAwesomeMap = e.hFontAwesomeMap
+	p.dpi = e.dpi
+	p.SetHandle(xc.XEle_Create(opt.X, opt.Y, opt.Width, opt.Height, hParent))
+	p.H = p.Handle
+	s := &iconPickerState{
+		query:      opt.Query,
+		style:      opt.Style,
+		category:   opt.Category,
+		cellWidth:  opt.CellWidth * e.dpi / 96,
+		cellHeight: opt.CellHeight * e.dpi / 96,
+		padding:    iconPickerPadding * e.dpi / 96,
+		scrollBar:  iconPickerScrollBar * e.dpi / 96,
+	}
+	e.addEle(&p.objBase, s)
+	s.painter = "onDrawIconPicker"
+	s.round = e.theme.BorderRadiusBase * e.dpi / 96
+	s.filter(Icons)
+	p.SetIconSize(opt.IconSize)
+
+	// 启用背景透明
+	p.EnableBkTransparent(true)
+
+	// 注册元素绘制事件
+	p.Event_PAINT1(onDrawEle)
+	// 注册鼠标移动事件, 用于显示鼠标停留的图标
+	p.Event_MOUSEMOVE1(onIconPickerMouseMove)
+	// 注册鼠标离开事件
+	p.Event_MOUSELEAVE1(onIconPickerMouseLeave)
+	// 注册鼠标左键弹起事件, 用于选中图标
+	p.Event_LBUTTONUP1(onIconPickerLButtonUp)
+	// 注册鼠标滚轮事件
+	p.Event_MOUSEWHEEL1(onIconPickerMouseWheel)
+	// 注册元素销毁事件
+	p.Event_DESTROY1(onDestroyEle)
+	return p
+}
+
+// SetQuery 设置搜索内容, 会重新筛选图标并回到顶部, 内部已自动重绘.
+//   - 搜索规则与 IconCatalog.Search 相同.
+//
+// query: 搜索内容, 为空时显示全部图标.
+func (p *IconPicker) SetQuery(query string) *IconPicker {
+	if s := p.pkState(); s != nil {
+		s.query = query
+	}
+	return p.refresh()
+}
+
+// GetQuery 获取搜索内容.
+func (p *IconPicker) GetQuery() string {
+	if s := p.pkState(); s != nil {
+		return s.query
+	}
+	return ""
+}
+
+// SetStyleFilter 设置只显示拥有指定风格的图标, 这些图标也会用这个风格显示, 内部已自动重绘.
+//
+// style: 风格, 如'fa-solid', 'fa-regular', 'fa-brands', 为空时显示全部.
+func (p *IconPicker) SetStyleFilter(style string) *IconPicker {
+	if s := p.pkState(); s != nil {
+		s.style = style
+	}
+	return p.refresh()
+}
+
+// GetStyleFilter 获取风格筛选.
+func (p *IconPicker) GetStyleFilter() string {
+	if s := p.pkState(); s != nil {
+		return s.style
+	}
+	return ""
+}
+
+// SetCategoryFilter 设置只显示指定分类的图标, 内部已自动重绘.
+//   - 可用的分类可通过 Icons.Categories 获取.
+//
+// category: 分类, 如'animals', 为空时显示全部.
+func (p *IconPicker) SetCategoryFilter(category string) *IconPicker {
+	if s := p.pkState(); s != nil {
+		s.category = category
+	}
+	return p.refresh()
+}
+
+// GetCategoryFilter 获取分类筛选.
+func (p *IconPicker) GetCategoryFilter() string {
+	if s := p.pkState(); s != nil {
+		return s.category
+	}
+	return ""
+}
+
+// GetCount 获取筛选后的图标数量.
+func (p *IconPicker) GetCount() int {
+	if s := p.pkState(); s != nil {
+		return len(s.icons)
+	}
+	return 0
+}
+
+// SetSelected 选中图标并滚动到能看到它的位置, 内部已自动重绘. 不会触发 SetOnSelect 设置的函数.
+//
+// name: 图标名, 可以是'fa-solid fa-paw', 'fa-paw' 或 'paw'. 为空时取消选中.
+func (p *IconPicker) SetSelected(name string) bool {
+	s := p.pkState()
+	if s == nil {
+		return false
+	}
+	if name == "" {
+		s.selected = ""
+		p.Redraw(false)
+		return true
+	}
+	icon, ok := Icons.Lookup(name)
+	if !ok {
+		return false
+	}
+	s.selected = icon.Prefix + icon.Name
+	s.scrollIntoView(s.selectedIndex(), p.GetWidth(), p.GetHeight())
+	p.Redraw(false)
+	return true
+}
+
+// GetSelected 获取选中的图标和它在选择器中显示的风格. 没有选中或选中的图标已被筛选掉时 ok 为 false.
+func (p *IconPicker) GetSelected() (icon Icon, style string, ok bool) {
+	s := p.pkState()
+	if s == nil {
+		return Icon{}, "", false
+	}
+	i := s.selectedIndex()
+	if i == -1 {
+		return Icon{}, "", false
+	}
+	return s.icons[i], s.iconStyle(i), true
+}
+
+// SetOnSelect 设置点击选中图标时调用的函数.
+//
+// f: 参数是选中的图标和它在选择器中显示的风格, 可用 icon.FullName(style) 得到能传给 SetIconName 的完整图标名.
+func (p *IconPicker) SetOnSelect(f func(icon Icon, style string)) *IconPicker {
+	if s := p.pkState(); s != nil {
+		s.onSelect = f
+	}
+	return p
+}
+
+// SetIconSize 设置图标大小, 与同一个 Elementui 对象创建的元素共用相同大小的字体, 内部已自动重绘.
+//   - 图标很大时需要在 IconPickerOption 中同时调大格子.
+//
+// size: 图标字体大小, 单位与 NewElementui 的 fontSize 相同. 小于 1 时使用 fontSize.
+func (p *IconPicker) SetIconSize(size int32) *IconPicker {
+	if size < 0 {
+		size = 0
+	}
+	s := p.pkState()
+	if s == nil {
+		return p
+	}
+	s.iconSize = size
+	s.fonts = make(map[string]int, len(p.hFontAwesomeMap))
+	for style := range p.hFontAwesomeMap {
+		s.fonts[style] = p.eui.getIconFont(style, size)
+	}
+	p.Redraw(false)
+	return p
+}
+
+// refresh 重新筛选图标后重绘.
+func (p *IconPicker) refresh() *IconPicker {
+	if s := p.pkState(); s != nil {
+		s.filter(Icons)
+	}
+	p.Redraw(false)
+	return p
+}
+
+// pkState 获取图标选择器的状态. 图标选择器没有记录状态时返回 nil.
+func (p *IconPicker) pkState() *iconPickerState {
+	return getIconPickerState(p.H)
+}
+
+// IconPickerOption 图标选择器选项.
+type IconPickerOption struct {
+	// 搜索内容, 规则与 IconCatalog.Search 相同.
+	Query string
+	// 只显示拥有这个风格的图标, 如'fa-solid', 'fa-regular', 'fa-brands', 为空时显示全部.
+	Style string
+	// 只显示这个分类的图标, 如'animals', 为空时显示全部.
+	Category string
+
+	// 格子的宽高, 默认 88x72.
+	CellWidth, CellHeight int32
+	// 图标大小, 单位与 NewElementui 的 fontSize 相同, 为 0 时使用 fontSize.
+	IconSize int32
+
+	// 默认宽高 480x320.
+	X, Y, Width, Height int32
+}
+
+// 图标选择器鼠标移动事件
+func onIconPickerMouseMove(hEle int, nFlags uint32, pPt *xc.POINT, pbHandled *bool) int {
+	if s := getIconPickerState(hEle); s != nil {
+		s.mouseStay = true
+		if i := s.hitTest(pPt.X, pPt.Y, xc.XEle_GetWidth(hEle)); i != s.hover {
+			s.hover = i
+			xc.XEle_Redraw(hEle, false)
+		}
+	}
+	return 0
+}
+
+// 图标选择器鼠标离开事件
+func onIconPickerMouseLeave(hEle int, hEleStay int, pbHandled *bool) int {
+	if s := getIconPickerState(hEle); s != nil {
+		s.mouseStay = false
+		s.hover = -1
+	}
+	xc.XEle_Redraw(hEle, false)
+	return 0
+}
+
+// 图标选择器鼠标左键弹起事件
+func onIconPickerLButtonUp(hEle int, nFlags uint32, pPt *xc.POINT, pbHandled *bool) int {
+	s := getIconPickerState(hEle)
+	if s == nil {
+		return 0
+	}
+	i := s.hitTest(pPt.X, pPt.Y, xc.XEle_GetWidth(hEle))
+	if i == -1 {
+		return 0
+	}
+	s.selected = s.icons[i].Prefix + s.icons[i].Name
+	xc.XEle_Redraw(hEle, false)
+	if s.onSelect != nil {
+		s.onSelect(s.icons[i], s.iconStyle(i))
+	}
+	return 0
+}
+
+// 图标选择器鼠标滚轮事件, 每滚动一格移动一行.
+//   - nFlags 与 WM_MOUSEWHEEL 的 wParam 相同, 高 16 位是滚动距离, 向上滚动为正.
+func onIconPickerMouseWheel(hEle int, nFlags uint32, pPt *xc.POINT, pbHandled *bool) int {
+	s := getIconPickerState(hEle)
+	if s == nil {
+		return 0
+	}
+	delta := int32(int16(nFlags >> 16))
+	y := s.scrollY - delta*s.cellHeight/120
+	if s.scrollTo(y, xc.XEle_GetWidth(hEle), xc.XEle_GetHeight(hEle)) {
+		// 滚动后鼠标下面的图标变了, 等下次鼠标移动时再确定
+		s.hover = -1
+		xc.XEle_Redraw(hEle, false)
+	}
+	*pbHandled = true
+	return 0
+}
@@ -0,0 +1,218 @@
+package eui
+
+// 图标选择器默认的格子大小和间距, 未按 dpi 缩放.
+const (
+	iconPickerCellWidth  = 88
+	iconPickerCellHeight = 72
+	iconPickerPadding    = 8
+	iconPickerScrollBar  = 6 // 滚动条宽度
+)
+
+// filter 根据搜索内容, 风格和分类从图标目录中重新筛选图标, 并回到顶部.
+//
+// c: 图标目录.
+func (s *iconPickerState) filter(c *IconCatalog) {
+	var list []Icon
+	if s.query != "" {
+		list = c.Search(s.query)
+	} else {
+		list = c.All()
+	}
+	icons := list[:0]
+	for _, icon := range list {
+		if s.style != "" && !icon.HasStyle(s.style) {
+			continue
+		}
+		if s.category != "" && !icon.HasCategory(s.category) {
+			continue
+		}
+		icons = append(icons, icon)
+	}
+	s.icons = icons
+	s.scrollY = 0
+	s.hover = -1
+}
+
+// iconStyle 返回图标在选择器中显示时使用的风格. 设置了风格筛选时使用筛选的风格.
+//
+// i: 图标在 icons 中的下标.
+func (s *iconPickerState) iconStyle(i int) string {
+	if s.style != "" {
+		return s.style
+	}
+	return s.icons[i].preferStyle("")
+}
+
+// selectedIndex 返回选中的图标在 icons 中的下标, 没有选中或被筛选掉时返回 -1.
+func (s *iconPickerState) selectedIndex() int {
+	if s.selected == "" {
+		return -1
+	}
+	for i, icon := range s.icons {
+		if icon.Prefix+icon.Name == s.selected {
+			return i
+		}
+	}
+	return -1
+}
+
+// columns 返回每行显示的图标数量, 至少为 1.
+//
+// width: 元素宽度.
+func (s *iconPickerState) columns(width int32) int {
+	n := (width - s.padding*2 - s.scrollBar) / s.cellWidth
+	if n < 1 {
+		return 1
+	}
+	return int(n)
+}
+
+// contentHeight 返回所有图标排列后的总高度, 包括上下间距.
+//
+// width: 元素宽度.
+func (s *iconPickerState) contentHeight(width int32) int32 {
+	cols := s.columns(width)
+	rows := (len(s.icons) + cols - 1) / cols
+	return int32(rows)*s.cellHeight + s.padding*2
+}
+
+// scrollTo 设置垂直滚动位置, 会限制在有效范围内. 返回滚动位置是否改变了.
+//
+// y: 滚动位置.
+//
+// width, height: 元素宽高.
+func (s *iconPickerState) scrollTo(y, width, height int32) bool {
+	maxY := s.contentHeight(width) - height
+	if y > maxY {
+		y = maxY
+	}
+	if y < 0 {
+		y = 0
+	}
+	if y == s.scrollY {
+		return false
+	}
+	s.scrollY = y
+	return true
+}
+
+// scrollIntoView 滚动到能完整看到指定图标的位置. 返回滚动位置是否改变了.
+//
+// i: 图标在 icons 中的下标.
+//
+// width, height: 元素宽高.
+func (s *iconPickerState) scrollIntoView(i int, width, height int32) bool {
+	if i < 0 || i >= len(s.icons) {
+		return false
+	}
+	rc := s.cellRect(i, width)
+	if rc.Top < s.padding {
+		return s.scrollTo(s.scrollY+rc.Top-s.padding, width, height)
+	} else if rc.Bottom > height-s.padding {
+		return s.scrollTo(s.scrollY+rc.Bottom-height+s.padding, width, height)
+	}
+	return false
+}
+
+// cellRect 返回图标格子在元素中的坐标, 已减去滚动位置.
+//
+// i: 图标在 icons 中的下标.
+//
+// width: 元素宽度.
+func (s *iconPickerState) cellRect(i int, width int32) Rect {
+	cols := s.columns(width)
+	x := s.padding + int32(i%cols)*s.cellWidth
+	y := s.padding + int32(i/cols)*s.cellHeight - s.scrollY
+	return Rect{Left: x, Top: y, Right: x + s.cellWidth, Bottom: y + s.cellHeight}
+}
+
+// hitTest 返回坐标所在的图标在 icons 中的下标, 不在图标上时返回 -1.
+//
+// x, y: 元素中的坐标.
+//
+// width: 元素宽度.
+func (s *iconPickerState) hitTest(x, y, width int32) int {
+	cols := s.columns(width)
+	x -= s.padding
+	y += s.scrollY - s.padding
+	if x < 0 || y < 0 || x >= int32(cols)*s.cellWidth {
+		return -1
+	}
+	i := int(y/s.cellHeight)*cols + int(x/s.cellWidth)
+	if i >= len(s.icons) {
+		return -1
+	}
+	return i
+}
+
+// 图标选择器绘制事件. 只绘制可见的几行图标, 图标再多也不会变慢.
+func onDrawIconPicker(ctx *PaintContext) {
+	s, ok := ctx.state.(*iconPickerState)
+	if !ok {
+		return
+	}
+	cv := ctx.Canvas
+	theme := ctx.Theme
+	width, height := ctx.Width, ctx.Height
+	rc := Rect{Right: width, Bottom: height}
+
+	// 绘制边框和背景
+	borderColor := theme.BorderColorBase
+	if ctx.Focus {
+		borderColor = theme.ColorPrimary
+	}
+	cv.SetBrushColor(borderColor)
+	cv.DrawRoundRect(rc, s.round)
+	cv.SetBrushColor(theme.BackgroundColor)
+	cv.FillRoundRect(offsetRect(rc, 1, 1, -1, -1), s.round)
+
+	if len(s.icons) == 0 {
+		cv.SetBrushColor(theme.ColorTextPlaceholder)
+		cv.SetTextAlign(TextAlign_Center | TextAlign_VCenter | TextAlign_NoWrap)
+		cv.DrawText("没有找到图标", rc)
+		return
+	}
+
+	// 只绘制和元素相交的行
+	cols := s.columns(width)
+	first := int((s.scrollY - s.padding) / s.cellHeight)
+	if first < 0 {
+		first = 0
+	}
+	last := int((s.scrollY+height-s.padding)/s.cellHeight) + 1
+	labelHeight := cv.TextSize("A", 0).CY
+	selected := s.selectedIndex()
+	for i := first * cols; i < last*cols && i < len(s.icons); i++ {
+		cell := offsetRect(s.cellRect(i, width), 2, 2, -2, -2)
+		iconColor := theme.ColorTextRegular
+		if i == selected {
+			iconColor = theme.ColorPrimary
+			cv.SetBrushColor(theme.ColorPrimary)
+			cv.DrawRoundRect(cell, theme.BorderRadiusBase)
+		} else if i == s.hover {
+			cv.SetBrushColor(theme.BackgroundColorBase)
+			cv.FillRoundRect(cell, theme.BorderRadiusBase)
+		}
+
+		icon := s.icons[i]
+		cv.SetTextAlign(TextAlign_Center | TextAlign_VCenter | TextAlign_NoWrap)
+		cv.SetFont(s.fonts[s.iconStyle(i)])
+		cv.SetBrushColor(iconColor)
+		cv.DrawText(icon.Char(), Rect{Left: cell.Left, Top: cell.Top, Right: cell.Right, Bottom: cell.Bottom - labelHeight})
+		cv.SetFont(0)
+		cv.SetBrushColor(theme.ColorTextSecondary)
+		cv.DrawText(icon.Name, Rect{Left: cell.Left + 2, Top: cell.Bottom - labelHeight - 4, Right: cell.Right - 2, Bottom: cell.Bottom - 4})
+	}
+
+	// 内容超出时绘制滚动条
+	if content := s.contentHeight(width); content > height {
+		thumb := height * height / content
+		if thumb < 20 {
+			thumb = 20
+		}
+		top := s.scrollY * (height - thumb) / (content - height)
+		bar := Rect{Left: width - s.scrollBar - 2, Top: top + 2, Right: width - 2, Bottom: top + thumb - 2}
+		cv.SetBrushColor(theme.BorderColorBase)
+		cv.FillRoundRect(bar, s.scrollBar/2)
+	}
+}
@@ -0,0 +1,121 @@
+package eui
+
+import "testing"
+
+// newTestPickerCatalog 创建图标选择器测试用的图标目录, 有 30 个图标.
+func newTestPickerCatalog() *IconCatalog {
+	c := &IconCatalog{}
+	var icons []Icon
+	for i := 0; i < 30; i++ {
+		icon := Icon{Name: string(rune('a'+i/10)) + string(rune('a'+i%10)), Prefix: "fa-", Styles: []string{"fa-solid"}, Unicode: 0xf000 + int32(i)}
+		if i%3 == 0 {
+			icon.Styles = append(icon.Styles, "fa-regular")
+		}
+		if i < 5 {
+			icon.Categories = []string{"animals"}
+		}
+		icons = append(icons, icon)
+	}
+	c.add(icons)
+	return c
+}
+
+// newTestPicker 创建格子大小 40x40, 间距 4 的图标选择器状态.
+func newTestPicker() *iconPickerState {
+	return &iconPickerState{cellWidth: 40, cellHeight: 40, padding: 4, scrollBar: iconPickerScrollBar, hover: -1}
+}
+
+func Test_iconPickerState_filter(t *testing.T) {
+	c := newTestPickerCatalog()
+	if got := c.Categories(); len(got) != 1 || got[0] != "animals" {
+		t.Errorf("Categories() = %v", got)
+	}
+
+	s := newTestPicker()
+	s.filter(c)
+	if len(s.icons) != 30 {
+		t.Errorf("no filter: %d icons", len(s.icons))
+	}
+	s.style = "fa-regular"
+	s.filter(c)
+	if len(s.icons) != 10 || s.iconStyle(0) != "fa-regular" {
+		t.Errorf("style filter: %d icons, style %q", len(s.icons), s.iconStyle(0))
+	}
+	s.style, s.category = "", "animals"
+	s.filter(c)
+	if len(s.icons) != 5 {
+		t.Errorf("category filter: %d icons", len(s.icons))
+	}
+	s.category, s.query = "", "b"
+	s.filter(c)
+	if len(s.icons) == 0 || s.icons[0].Name != "ba" {
+		t.Errorf("query filter: %v", s.icons)
+	}
+
+	// 选中的图标被筛选掉后再筛选回来仍是选中的
+	s.selected = "fa-aa"
+	if s.selectedIndex() != -1 {
+		t.Errorf("selected icon should be filtered out")
+	}
+	s.query = ""
+	s.filter(c)
+	if s.selectedIndex() != 0 {
+		t.Errorf("selectedIndex() = %d", s.selectedIndex())
+	}
+}
+
+func Test_iconPickerState_layout(t *testing.T) {
+	s := newTestPicker()
+	s.filter(newTestPickerCatalog())
+
+	// 宽 180: (180 - 8 - 6) / 40 = 4 列, 30 个图标 8 行
+	const width, height = 180, 100
+	if cols := s.columns(width); cols != 4 {
+		t.Fatalf("columns() = %d", cols)
+	}
+	if h := s.contentHeight(width); h != 8*40+8 {
+		t.Errorf("contentHeight() = %d", h)
+	}
+	if i := s.hitTest(4+40+1, 4+1, width); i != 1 {
+		t.Errorf("hitTest() = %d, want 1", i)
+	}
+	if i := s.hitTest(2, 2, width); i != -1 {
+		t.Errorf("hitTest(padding) = %d, want -1", i)
+	}
+	// 最后一行只有 2 个图标
+	if i := s.hitTest(4+2*40+1, 4+7*40+1, width); i != -1 {
+		t.Errorf("hitTest(after last icon) = %d, want -1", i)
+	}
+
+	if !s.scrollTo(1000, width, height) || s.scrollY != 8*40+8-height {
+		t.Errorf("scrollTo(1000) = %d", s.scrollY)
+	}
+	if i := s.hitTest(4+1, height-4-1, width); i != 28 {
+		t.Errorf("hitTest(scrolled) = %d, want 28", i)
+	}
+	s.scrollTo(0, width, height)
+	if !s.scrollIntoView(12, width, height) || s.cellRect(12, width).Bottom != height-4 {
+		t.Errorf("scrollIntoView(12): scrollY = %d", s.scrollY)
+	}
+	if s.scrollIntoView(12, width, height) {
+		t.Errorf("scrollIntoView should not scroll when the icon is visible")
+	}
+}
+
+func Test_iconPickerState_filterEmbeddedCategory(t *testing.T) {
+	skipWithoutIconData(t)
+	// 'animals' 是 FontAwesome categories.yml 中的分类, fa-paw 属于它
+	s := newTestPicker()
+	s.category = "animals"
+	s.filter(Icons)
+	if len(s.icons) == 0 || len(s.icons) == Icons.Len() {
+		t.Fatalf("filter by category animals: %d icons", len(s.icons))
+	}
+	found := false
+	for _, icon := range s.icons {
+		found = found || icon.Name == "paw"
+	}
+	if !found {
+		t.Errorf("category animals does not contain paw")
+	}
+}
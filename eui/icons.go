@@ -0,0 +1,410 @@
+package eui
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Icon 图标信息.
+type Icon struct {
+	Name    string   // 图标名, 不带风格和前缀, 如'paw'
+	Prefix  string   // 图标名前缀, FontAwesome 是'fa-'
+	Styles  []string // 图标拥有的风格, 如'fa-solid', 'fa-regular', 'fa-brands'
+	Unicode int32    // Unicode 码点
+
+	Label      string   // 图标的显示名, 如'Paw', 可能为空
+	Terms      []string // 搜索词, 如'animal', 'pet', 可能为空
+	Aliases    []string // 别名, 不带前缀, 如 house 的'home', 一般是 FontAwesome 5 的旧图标名, 可能为空
+	Categories []string // 图标所属的分类, 如'animals', 可能为空
+}
+
+// FullName 返回带风格的完整图标名, 如'fa-solid fa-paw', 可直接传给 SetIconName.
+//
+// style: 风格, 为空时使用图标的第一个风格.
+func (i Icon) FullName(style string) string {
+	if style == "" && len(i.Styles) > 0 {
+		style = i.Styles[0]
+	}
+	return style + " " + i.Prefix + i.Name
+}
+
+// Hex 返回 Unicode 码点的十六进制文本, 如'f1b0', 可直接传给 SetIconHex.
+func (i Icon) Hex() string {
+	return strconv.FormatInt(int64(i.Unicode), 16)
+}
+
+// Char 返回图标对应的字符.
+func (i Icon) Char() string {
+	return string(i.Unicode)
+}
+
+// HasStyle 判断图标是否拥有指定的风格.
+//
+// style: 风格, 如'fa-solid'.
+func (i Icon) HasStyle(style string) bool {
+	for _, s := range i.Styles {
+		if s == style {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCategory 判断图标是否属于指定的分类.
+//
+// category: 分类, 如'animals'.
+func (i Icon) HasCategory(category string) bool {
+	for _, c := range i.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// IconCatalog 图标目录, 存放所有可用的图标, 可按名字, 码点查找和搜索.
+//   - 内置的 FontAwesome 图标和 Elementui.RegisterIconFont 注册的图标都在 Icons 中.
+//   - 只能在 UI 线程中修改.
+type IconCatalog struct {
+	icons     []Icon          // 按图标名排序
+	byName    map[string]int  // 带前缀的图标名对应 icons 的下标, 如'fa-paw'
+	byAlias   map[string]int  // 带前缀的别名对应 icons 的下标, 如'fa-home'
+	byUnicode map[int32][]int // 码点对应 icons 的下标, 不同字体的码点可能相同, 先注册的在前面
+	seq       map[string]int  // 带前缀的图标名对应的注册顺序
+}
+
+// Icons 是内置的 FontAwesome 图标目录.
+var Icons = &IconCatalog{}
+
+// 元素自带功能用到的图标.
+
+const (
+	iconEditClear        = "fa-regular fa-circle-xmark" // 编辑框的清空图标
+	iconEditPasswordShow = "fa-regular fa-eye"          // 密码框隐藏密码时的显示密码图标
+	iconEditPasswordHide = "fa-regular fa-eye-slash"    // 密码框显示密码时的隐藏密码图标
+)
+
+// BuiltinIcons 是元素自带功能用到的图标, 如编辑框的清空图标. euisubset 总会打包这些图标.
+var BuiltinIcons = []string{iconEditClear, iconEditPasswordShow, iconEditPasswordHide}
+
+// add 添加图标后重建索引. 带前缀的图标名已存在时合并风格, 搜索词, 别名和分类.
+func (c *IconCatalog) add(icons []Icon) {
+	if c.byName == nil {
+		c.byName = make(map[string]int)
+		c.seq = make(map[string]int)
+	}
+	for _, icon := range icons {
+		key := icon.Prefix + icon.Name
+		if i, ok := c.byName[key]; ok {
+			old := &c.icons[i]
+			old.Styles = mergeStrings(old.Styles, icon.Styles)
+			old.Terms = mergeStrings(old.Terms, icon.Terms)
+			old.Aliases = mergeStrings(old.Aliases, icon.Aliases)
+			old.Categories = mergeStrings(old.Categories, icon.Categories)
+			if old.Label == "" {
+				old.Label = icon.Label
+			}
+			continue
+		}
+		c.seq[key] = len(c.seq)
+		c.icons = append(c.icons, icon)
+		c.byName[key] = len(c.icons) - 1
+	}
+
+	sort.Slice(c.icons, func(i, j int) bool {
+		return c.icons[i].Prefix+c.icons[i].Name < c.icons[j].Prefix+c.icons[j].Name
+	})
+	c.byUnicode = make(map[int32][]int, len(c.icons))
+	c.byAlias = make(map[string]int)
+	for i, icon := range c.icons {
+		c.byName[icon.Prefix+icon.Name] = i
+		c.byUnicode[icon.Unicode] = append(c.byUnicode[icon.Unicode], i)
+		for _, alias := range icon.Aliases {
+			c.byAlias[icon.Prefix+alias] = i
+		}
+	}
+	// 码点相同时先注册的在前面
+	for _, idx := range c.byUnicode {
+		if len(idx) > 1 {
+			sort.Slice(idx, func(a, b int) bool {
+				return c.seq[c.icons[idx[a]].Prefix+c.icons[idx[a]].Name] < c.seq[c.icons[idx[b]].Prefix+c.icons[idx[b]].Name]
+			})
+		}
+	}
+}
+
+// Register 注册图标到目录中, 注册后 Lookup, Search 等函数就能找到这些图标.
+//   - 一般使用 Elementui.RegisterIconFont, 它会同时创建字体并调用本函数.
+//
+// style: 风格, 如'acme-solid'. 第一个'-'及前面的部分是图标名前缀, 如'acme-'.
+//
+// icons: 图标名对应的 Unicode 码点, 图标名可带或不带前缀, 如'acme-logo'或'logo'.
+func (c *IconCatalog) Register(style string, icons map[string]int32) error {
+	if err := checkIconStyle(style); err != nil {
+		return err
+	}
+	prefix := iconPrefix(style)
+	list := make([]Icon, 0, len(icons))
+	for name, u := range icons {
+		name = strings.TrimPrefix(strings.TrimSpace(name), prefix)
+		if name == "" || u <= 0 {
+			continue
+		}
+		list = append(list, Icon{Name: name, Prefix: prefix, Styles: []string{style}, Unicode: u})
+	}
+	// 按名字排序后添加, 使码点相同的图标的顺序是确定的
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	c.add(list)
+	return nil
+}
+
+// Len 返回图标数量.
+func (c *IconCatalog) Len() int {
+	return len(c.icons)
+}
+
+// Lookup 根据图标名查找图标.
+//
+//   - 找不到时会按别名查找, 所以 FontAwesome 5 的旧图标名也能找到, 如'fa-home'会找到'fa-house'.
+//
+// name: 图标名, 可以是'fa-solid fa-paw', 'fa-paw' 或 'paw'. 带风格时图标必须拥有这个风格. 注册的图标需带前缀, 如'acme-solid acme-logo', 'acme-logo'.
+func (c *IconCatalog) Lookup(name string) (Icon, bool) {
+	style, short := splitIconName(name)
+	i, ok := c.byName[short]
+	if !ok {
+		i, ok = c.byName["fa-"+short]
+	}
+	if !ok {
+		i, ok = c.byAlias[short]
+	}
+	if !ok {
+		i, ok = c.byAlias["fa-"+short]
+	}
+	if !ok {
+		return Icon{}, false
+	}
+	icon := c.icons[i]
+	if style != "" && !icon.HasStyle(style) {
+		return Icon{}, false
+	}
+	return icon, true
+}
+
+// Resolve 根据图标名查找图标, 并确定显示时使用的风格, 与 SetIconName 的规则相同.
+//   - 图标名带风格时使用这个风格, 否则按'fa-solid', 'fa-brands', 'fa-regular'的顺序选择, 都没有时使用图标的第一个风格.
+//
+// name: 图标名, 规则与 Lookup 相同.
+func (c *IconCatalog) Resolve(name string) (icon Icon, style string, ok bool) {
+	icon, ok = c.Lookup(name)
+	if !ok {
+		return Icon{}, "", false
+	}
+	style, _ = splitIconName(name)
+	return icon, icon.preferStyle(style), true
+}
+
+// ByCodepoint 根据 Unicode 码点查找图标. 多个字体中都有这个码点时返回先注册的, 内置的 FontAwesome 图标最先注册.
+//
+// u: Unicode 码点, 如 61872 或 0xf1b0.
+func (c *IconCatalog) ByCodepoint(u int32) (Icon, bool) {
+	if idx := c.byUnicode[u]; len(idx) > 0 {
+		return c.icons[idx[0]], true
+	}
+	return Icon{}, false
+}
+
+// Styles 返回图标拥有的风格, 找不到图标时返回 nil.
+//
+// name: 图标名, 可以是'fa-solid fa-paw', 'fa-paw' 或 'paw'.
+func (c *IconCatalog) Styles(name string) []string {
+	icon, ok := c.Lookup(name)
+	if !ok {
+		return nil
+	}
+	return append([]string(nil), icon.Styles...)
+}
+
+// Search 搜索图标, 不区分大小写.
+//   - 结果依次是: 图标名以 query 开头的, 图标名包含 query 的, 别名, 搜索词或显示名包含 query 的(同义词), 图标名按顺序包含 query 中所有字符的(模糊匹配). 每组内按图标名排序.
+//   - query 可以带风格, 如'fa-regular fa-add', 这时只返回拥有这个风格的图标.
+//   - query 为空时返回所有图标.
+//
+// query: 搜索内容.
+func (c *IconCatalog) Search(query string) []Icon {
+	style, q := splitIconName(strings.ToLower(query))
+	var prefix, contains, synonym, fuzzy []Icon
+	for _, icon := range c.icons {
+		if style != "" && !icon.HasStyle(style) {
+			continue
+		}
+		// 查询内容带前缀时与带前缀的图标名比较
+		name := icon.Name
+		if strings.HasPrefix(q, icon.Prefix) {
+			name = icon.Prefix + icon.Name
+		}
+		switch {
+		case strings.HasPrefix(name, q):
+			prefix = append(prefix, icon)
+		case strings.Contains(name, q):
+			contains = append(contains, icon)
+		case icon.matchSynonym(strings.TrimPrefix(q, icon.Prefix)):
+			synonym = append(synonym, icon)
+		case isSubsequence(q, name):
+			fuzzy = append(fuzzy, icon)
+		}
+	}
+	return append(append(append(prefix, contains...), synonym...), fuzzy...)
+}
+
+// Range 按图标名顺序遍历所有图标, f 返回 false 时停止遍历.
+//
+// f: 遍历函数.
+func (c *IconCatalog) Range(f func(icon Icon) bool) {
+	for _, icon := range c.icons {
+		if !f(icon) {
+			return
+		}
+	}
+}
+
+// All 按图标名顺序返回所有图标.
+func (c *IconCatalog) All() []Icon {
+	return append([]Icon(nil), c.icons...)
+}
+
+// Categories 按名字顺序返回所有图标的分类, 没有图标带分类时返回 nil.
+func (c *IconCatalog) Categories() []string {
+	seen := make(map[string]bool)
+	var list []string
+	for _, icon := range c.icons {
+		for _, category := range icon.Categories {
+			if !seen[category] {
+				seen[category] = true
+				list = append(list, category)
+			}
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// StyleOf 返回码点对应的图标要使用的风格, 找不到图标时返回空.
+//   - 有图标拥有 prefer 风格时返回 prefer, 否则按'fa-solid', 'fa-brands', 'fa-regular'的顺序选择, 都没有时返回先注册的图标的第一个风格. 同一个码点总是得到同一个风格.
+//
+// u: Unicode 码点.
+//
+// prefer: 优先使用的风格, 可为空.
+func (c *IconCatalog) StyleOf(u int32, prefer string) string {
+	idx := c.byUnicode[u]
+	if len(idx) == 0 {
+		return ""
+	}
+	if prefer != "" {
+		for _, i := range idx {
+			if c.icons[i].HasStyle(prefer) {
+				return prefer
+			}
+		}
+	}
+	return c.icons[idx[0]].preferStyle("")
+}
+
+// preferStyle 返回图标要使用的风格. 图标拥有 prefer 风格时返回 prefer, 否则按'fa-solid', 'fa-brands', 'fa-regular'的顺序选择, 都没有时返回第一个风格.
+func (i Icon) preferStyle(prefer string) string {
+	if prefer != "" && i.HasStyle(prefer) {
+		return prefer
+	}
+	for _, style := range defaultIconStyles {
+		if i.HasStyle(style) {
+			return style
+		}
+	}
+	if len(i.Styles) > 0 {
+		return i.Styles[0]
+	}
+	return ""
+}
+
+// matchSynonym 判断图标的别名, 搜索词或显示名是否包含 q, 不区分大小写.
+//
+// q: 小写的搜索内容, 不带前缀.
+func (i Icon) matchSynonym(q string) bool {
+	if q == "" {
+		return false
+	}
+	for _, alias := range i.Aliases {
+		if strings.Contains(alias, q) {
+			return true
+		}
+	}
+	for _, term := range i.Terms {
+		if strings.Contains(strings.ToLower(term), q) {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(i.Label), q)
+}
+
+// defaultIconStyles 是没有指定风格时依次尝试的风格.
+var defaultIconStyles = []string{"fa-solid", "fa-brands", "fa-regular"}
+
+// splitIconName 把图标名拆分成风格和不带风格的图标名.
+//
+// name: 图标名, 可以是'fa-solid fa-paw', 'fa-paw' 或 'paw'.
+func splitIconName(name string) (style, short string) {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndex(name, " "); i != -1 {
+		style = strings.TrimSpace(name[:i])
+		name = name[i+1:]
+	}
+	return style, name
+}
+
+// checkIconStyle 检查风格是否可用, 风格不能为空, 也不能包含空白字符.
+func checkIconStyle(style string) error {
+	if style == "" || strings.ContainsAny(style, " \t\r\n") {
+		return errors.New("invalid icon style: '" + style + "'")
+	}
+	return nil
+}
+
+// iconPrefix 返回风格对应的图标名前缀, 是风格中第一个'-'及前面的部分, 如'fa-solid'的前缀是'fa-'.
+func iconPrefix(style string) string {
+	if i := strings.Index(style, "-"); i != -1 {
+		return style[:i+1]
+	}
+	return style + "-"
+}
+
+// mergeStrings 把 src 中 dst 没有的字符串添加到 dst 后面.
+func mergeStrings(dst, src []string) []string {
+	for _, s := range src {
+		found := false
+		for _, d := range dst {
+			if d == s {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, s)
+		}
+	}
+	return dst
+}
+
+// isSubsequence 判断 s 中的字符是否按顺序都出现在 t 中.
+func isSubsequence(s, t string) bool {
+	for _, r := range s {
+		i := strings.IndexRune(t, r)
+		if i == -1 {
+			return false
+		}
+		t = t[i+len(string(r)):]
+	}
+	return true
+}
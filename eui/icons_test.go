@@ -0,0 +1,258 @@
+package eui
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_IconCatalog(t *testing.T) {
+	skipWithoutIconData(t)
+	if n := Icons.Len(); n < 1800 {
+		t.Errorf("Len() = %d", n)
+	}
+	for _, name := range []string{"fa-solid fa-paw", "fa-paw", "paw"} {
+		if icon, ok := Icons.Lookup(name); !ok || icon.Unicode != 0xf1b0 || icon.Hex() != "f1b0" {
+			t.Errorf("Lookup(%q) = %+v, %v", name, icon, ok)
+		}
+	}
+	if _, ok := Icons.Lookup("fa-brands fa-paw"); ok {
+		t.Errorf("Lookup with a style the icon does not have should fail")
+	}
+	if icon, ok := Icons.ByCodepoint(0xf1b0); !ok || icon.FullName("") != "fa-solid fa-paw" {
+		t.Errorf("ByCodepoint(0xf1b0) = %+v, %v", icon, ok)
+	}
+	if styles := Icons.Styles("address-book"); len(styles) != 2 {
+		t.Errorf("Styles(address-book) = %v", styles)
+	}
+	for name, want := range map[string]string{"address-book": "fa-solid", "fa-regular fa-address-book": "fa-regular", "fa-github": "fa-brands", "home": "fa-solid"} {
+		if icon, style, ok := Icons.Resolve(name); !ok || style != want || icon.Unicode == 0 {
+			t.Errorf("Resolve(%q) = %+v, %q, %v", name, icon, style, ok)
+		}
+	}
+	if _, _, ok := Icons.Resolve("fa-brands fa-address-book"); ok {
+		t.Errorf("Resolve with a style the icon does not have should fail")
+	}
+
+	// 前缀匹配排在包含和模糊匹配的前面
+	result := Icons.Search("Book")
+	if len(result) == 0 || result[0].Name != "book" {
+		t.Fatalf("Search(Book) = %v", result)
+	}
+	found := false
+	for _, icon := range Icons.Search("adrbk") {
+		found = found || icon.Name == "address-book"
+	}
+	if !found {
+		t.Errorf("fuzzy Search(adrbk) does not contain address-book")
+	}
+	for _, icon := range Icons.Search("fa-brands fa-git") {
+		if !icon.HasStyle("fa-brands") {
+			t.Errorf("Search with style returned %+v", icon)
+		}
+	}
+
+	n := 0
+	Icons.Range(func(icon Icon) bool {
+		n++
+		return n < 10
+	})
+	if n != 10 {
+		t.Errorf("Range did not stop, n = %d", n)
+	}
+}
+
+func Test_IconCatalog_StyleOf(t *testing.T) {
+	skipWithoutIconData(t)
+	// address-book 同时有 regular 和 solid 风格
+	const addressBook = 0xf2b9
+	tests := []struct {
+		prefer, want string
+	}{
+		{"", "fa-solid"},
+		{"fa-regular", "fa-regular"},
+		{"fa-brands", "fa-solid"},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 10; i++ {
+			if got := Icons.StyleOf(addressBook, tt.prefer); got != tt.want {
+				t.Fatalf("StyleOf(address-book, %q) = %q, want %q", tt.prefer, got, tt.want)
+			}
+		}
+	}
+	if got := Icons.StyleOf(0xf09b, ""); got != "fa-brands" { // github
+		t.Errorf("StyleOf(github) = %q", got)
+	}
+	if got := Icons.StyleOf(1, ""); got != "" {
+		t.Errorf("StyleOf(unknown) = %q", got)
+	}
+}
+
+func Test_IconCatalog_Register(t *testing.T) {
+	c := &IconCatalog{}
+	if err := c.Register("fa-solid", map[string]int32{"star": 0xf005, "paw": 0xf1b0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Register("acme-solid", map[string]int32{"acme-logo": 0xe001, "star": 0xf005}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Register("acme-regular", map[string]int32{"logo": 0xe001}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Register("bad style", nil); err == nil {
+		t.Errorf("Register with a space in style should fail")
+	}
+
+	icon, ok := c.Lookup("acme-solid acme-logo")
+	if !ok || icon.Unicode != 0xe001 || icon.FullName("acme-regular") != "acme-regular acme-logo" {
+		t.Errorf("Lookup(acme-solid acme-logo) = %+v, %v", icon, ok)
+	}
+	if styles := c.Styles("acme-logo"); len(styles) != 2 {
+		t.Errorf("Styles(acme-logo) = %v", styles)
+	}
+	// 码点相同时先注册的优先
+	if icon, _ := c.ByCodepoint(0xf005); icon.Prefix != "fa-" {
+		t.Errorf("ByCodepoint(0xf005) = %+v, want the fa- icon", icon)
+	}
+	if got := c.StyleOf(0xf005, "acme-solid"); got != "acme-solid" {
+		t.Errorf("StyleOf(0xf005, acme-solid) = %q", got)
+	}
+	if got := c.StyleOf(0xe001, ""); got != "acme-solid" {
+		t.Errorf("StyleOf(0xe001) = %q", got)
+	}
+	if result := c.Search("acme-"); len(result) != 2 {
+		t.Errorf("Search(acme-) = %v", result)
+	}
+	if icon, ok := c.Lookup("paw"); !ok || icon.FullName("") != "fa-solid fa-paw" {
+		t.Errorf("Lookup(paw) = %+v, %v", icon, ok)
+	}
+}
+
+func Test_IconCatalog_Metadata(t *testing.T) {
+	skipWithoutIconData(t)
+	// FontAwesome 5 的旧图标名
+	for _, name := range []string{"fa-home", "home", "fa-solid fa-home"} {
+		if icon, ok := Icons.Lookup(name); !ok || icon.Name != "house" {
+			t.Errorf("Lookup(%q) = %+v, %v", name, icon, ok)
+		}
+	}
+	if _, ok := Icons.Lookup("fa-brands fa-home"); ok {
+		t.Errorf("Lookup with a style the icon does not have should fail")
+	}
+
+	c := &IconCatalog{}
+	c.add([]Icon{
+		{Name: "house", Prefix: "fa-", Styles: []string{"fa-solid"}, Unicode: 0xf015, Aliases: []string{"home"}, Terms: []string{"Abode"}},
+		{Name: "home-x", Prefix: "fa-", Styles: []string{"fa-solid"}, Unicode: 0xf000},
+		{Name: "cat", Prefix: "fa-", Styles: []string{"fa-solid"}, Unicode: 0xf6be, Label: "Kitty"},
+	})
+	// 图标名匹配排在同义词匹配前面
+	result := c.Search("home")
+	if len(result) != 2 || result[0].Name != "home-x" || result[1].Name != "house" {
+		t.Errorf("Search(home) = %v", result)
+	}
+	if result := c.Search("fa-home"); len(result) != 2 || result[1].Name != "house" {
+		t.Errorf("Search(fa-home) = %v", result)
+	}
+	if result := c.Search("abode"); len(result) != 1 || result[0].Name != "house" {
+		t.Errorf("Search(abode) = %v", result)
+	}
+	if result := c.Search("kitty"); len(result) != 1 || result[0].Name != "cat" {
+		t.Errorf("Search(kitty) = %v", result)
+	}
+	// 图标名优先于别名
+	if icon, ok := c.Lookup("home-x"); !ok || icon.Name != "home-x" {
+		t.Errorf("Lookup(home-x) = %+v", icon)
+	}
+}
+
+func Test_Icons_EmbeddedMetadata(t *testing.T) {
+	skipWithoutIconData(t)
+	// res/icons.min.json 要用 go generate 根据 FontAwesome 的 metadata 目录生成, 手工修改的文件没有显示名, 搜索词和分类
+	categories := Icons.Categories()
+	if len(categories) == 0 {
+		t.Error("res/icons.min.json 没有分类, 需设置 FA_METADATA 后执行 go generate 重新生成")
+	}
+	icon, ok := Icons.Lookup("house")
+	if !ok || icon.Label == "" || len(icon.Terms) == 0 || len(icon.Categories) == 0 {
+		t.Errorf("Lookup(house) = %+v, %v", icon, ok)
+	}
+	found := false
+	for _, icon := range Icons.Search("abode") {
+		found = found || icon.Name == "house"
+	}
+	if !found {
+		t.Errorf("Search(abode) does not contain house")
+	}
+	// 每个分类都能筛选出图标
+	for _, category := range categories {
+		s := &iconPickerState{category: category}
+		s.filter(Icons)
+		if len(s.icons) == 0 || len(s.icons) == Icons.Len() {
+			t.Errorf("filter by category %q: %d icons", category, len(s.icons))
+		}
+	}
+}
+
+func Test_LoadIconSubset(t *testing.T) {
+	skipWithoutIconData(t)
+	fonts := map[string][]byte{"fa-solid": IconFontData("fa-solid"), "fa-regular": IconFontData("fa-regular"), "fa-brands": IconFontData("fa-brands")}
+	defer func() {
+		if err := LoadIconSubset(fonts, fontAwesomeJson); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	err := LoadIconSubset(map[string][]byte{"fa-solid": {1}}, []byte(`{"house":{"a":["home"],"s":["solid"],"u":61461}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Icons.Len() != 1 || IconFontData("fa-solid")[0] != 1 || IconFontData("fa-regular") != nil {
+		t.Errorf("Len() = %d", Icons.Len())
+	}
+	if icon, ok := Icons.Lookup("fa-home"); !ok || icon.Unicode != 0xf015 {
+		t.Errorf("Lookup(fa-home) = %+v, %v", icon, ok)
+	}
+	if err := LoadIconSubset(nil, []byte("{")); err == nil {
+		t.Errorf("LoadIconSubset with invalid json should fail")
+	}
+}
+
+func Test_Elementui_IconSvgText(t *testing.T) {
+	skipWithoutIconData(t)
+	e := &Elementui{}
+	svg, err := e.IconSvgText("fa-paw", 16, rgba(0x40, 0x9e, 0xff, 255))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`width="16" height="16"`, `viewBox="0 11 512 512"`, `fill="#409eff"`, `d="M`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("svg should contain %s: %.200s", want, svg)
+		}
+	}
+	if strings.Contains(svg, "fill-opacity") {
+		t.Error("opaque color should not have fill-opacity")
+	}
+
+	// 宽图标按宽度放大视图, 半透明颜色
+	svg, err = e.IconSvgText("fa-regular fa-address-card", 24, rgba(0, 0, 0, 128))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`viewBox="0 -24 576 576"`, `fill="#000000" fill-opacity="0.502"`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("svg should contain %s: %.200s", want, svg)
+		}
+	}
+
+	if _, err := e.IconSvgText("fa-not-an-icon", 16, 0); err == nil {
+		t.Error("want error for unknown icon")
+	}
+}
+
+// skipWithoutIconData 使用 eui_subset 构建标签时没有内置的图标数据, 跳过依赖它的测试.
+func skipWithoutIconData(t *testing.T) {
+	t.Helper()
+	if len(fontAwesomeJson) == 0 {
+		t.Skip("eui_subset 构建标签没有内置图标数据")
+	}
+}
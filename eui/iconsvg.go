@@ -0,0 +1,67 @@
+package eui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/twgh/xc-elementui/internal/fontsubset"
+)
+
+// IconSvgText 把图标的字形轮廓转换成 svg 文本, 不需要字体句柄, 可用于 xcgui 的列表, 菜单, 托盘图标, 窗口标题等不能使用 eui 图标的地方.
+//   - 图标放在 size x size 的正方形中, 与在文字中显示时一样垂直居中, 宽图标水平方向会超出 em 宽度, 此时按宽度缩小.
+//   - 可以使用 RegisterIconFont 注册的图标.
+//
+// name: 图标名, 可以是'fa-solid fa-paw', 'fa-paw' 或 'paw'. 没有风格时按'fa-solid', 'fa-brands', 'fa-regular'的顺序选择.
+//
+// size: 图标大小, 单位是像素.
+//
+// color: 图标颜色, xc.RGBA 的返回值.
+func (e *Elementui) IconSvgText(name string, size int32, color uint32) (string, error) {
+	icon, style, ok := Icons.Resolve(name)
+	if !ok {
+		return "", errors.New("icon not found: " + name)
+	}
+	ttf := e.iconFontData[style]
+	if ttf == nil {
+		ttf = IconFontData(style)
+	}
+	if len(ttf) == 0 {
+		return "", errors.New("no font data for icon style: " + style)
+	}
+	g, err := fontsubset.Outline(ttf, icon.Unicode)
+	if err != nil {
+		return "", err
+	}
+	return glyphSvg(g, size, color), nil
+}
+
+// glyphSvg 生成字形的 svg 文本.
+//   - 视图是以字符中心为中心的 em 正方形, 垂直方向的中心是上升线和下降线的中间. 字形比 em 宽时视图按字形宽度扩大.
+//
+// g: 字形轮廓.
+//
+// size: svg 的宽高.
+//
+// color: 填充颜色, xc.RGBA 的返回值.
+func glyphSvg(g fontsubset.Glyph, size int32, color uint32) string {
+	em := g.UnitsPerEm
+	if em <= 0 {
+		em = g.Ascent - g.Descent
+	}
+	view := em
+	if g.Advance > view {
+		view = g.Advance
+	}
+	// 路径的 y 坐标以上升线为 0, 视图顶部在字符中心上方 view/2 处
+	x := (g.Advance - view) / 2
+	y := (g.Ascent-g.Descent)/2 - view/2
+
+	r, gr, b, a := byte(color), byte(color>>8), byte(color>>16), byte(color>>24)
+	fill := fmt.Sprintf(`fill="#%02x%02x%02x"`, r, gr, b)
+	if a != 255 {
+		fill += ` fill-opacity="` + strconv.FormatFloat(float64(a)/255, 'f', 3, 64) + `"`
+	}
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="%d %d %d %d"><path %s d="%s"/></svg>`,
+		size, size, x, y, view, view, fill, g.Path)
+}
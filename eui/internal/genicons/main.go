@@ -0,0 +1,179 @@
+// genicons 根据 FontAwesome 的元数据生成 eui 内置的 res/icons.min.json.
+//
+// 用法:
+//
+//	go run ./internal/genicons -metadata fontawesome-free-6.6.0-web/metadata -out res/icons.min.json
+//
+// metadata 目录中需要有 icons.json 和 categories.yml. 生成的 json 以图标名为键, 值的字段如下:
+//   - a: 别名, FontAwesome 5 的旧图标名
+//   - c: 分类
+//   - l: 显示名
+//   - s: 免费的风格
+//   - t: 搜索词
+//   - u: Unicode 码点
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// faIcon 是 FontAwesome 元数据 icons.json 中的图标信息, 只列出了用到的字段.
+type faIcon struct {
+	Aliases struct {
+		Names []string `json:"names"`
+	} `json:"aliases"`
+	Label  string `json:"label"`
+	Search struct {
+		Terms []string `json:"terms"`
+	} `json:"search"`
+	Styles  []string `json:"styles"`
+	Free    []string `json:"free"`
+	Unicode string   `json:"unicode"`
+}
+
+// minIcon 是生成的 icons.min.json 中的图标信息, 字段按键名排序.
+type minIcon struct {
+	Aliases    []string `json:"a,omitempty"`
+	Categories []string `json:"c,omitempty"`
+	Label      string   `json:"l,omitempty"`
+	Styles     []string `json:"s"`
+	Terms      []string `json:"t,omitempty"`
+	Unicode    int32    `json:"u"`
+}
+
+func main() {
+	metadata := flag.String("metadata", "", "FontAwesome 的 metadata 目录")
+	out := flag.String("out", "res/icons.min.json", "生成的 json 文件")
+	flag.Parse()
+	if *metadata == "" {
+		fmt.Fprintln(os.Stderr, "genicons: 需要使用 -metadata 指定 FontAwesome 的 metadata 目录")
+		os.Exit(2)
+	}
+	if err := run(*metadata, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "genicons:", err)
+		os.Exit(1)
+	}
+}
+
+func run(metadata, out string) error {
+	f, err := os.Open(filepath.Join(metadata, "icons.json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var faIcons map[string]faIcon
+	if err := json.NewDecoder(f).Decode(&faIcons); err != nil {
+		return errors.New("decoding icons.json failed: " + err.Error())
+	}
+
+	cf, err := os.Open(filepath.Join(metadata, "categories.yml"))
+	if err != nil {
+		return err
+	}
+	defer cf.Close()
+	categories, err := parseCategories(cf)
+	if err != nil {
+		return err
+	}
+
+	icons, err := convert(faIcons, categories)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(icons)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, data, 0644)
+}
+
+// convert 把 icons.json 中的图标转换成 icons.min.json 的格式, 没有免费风格的图标会被去掉.
+//
+// faIcons: icons.json 中的图标.
+//
+// categories: 图标名对应的分类.
+func convert(faIcons map[string]faIcon, categories map[string][]string) (map[string]minIcon, error) {
+	icons := make(map[string]minIcon, len(faIcons))
+	for name, fa := range faIcons {
+		styles := fa.Free
+		if styles == nil {
+			styles = fa.Styles
+		}
+		if len(styles) == 0 {
+			continue
+		}
+		u, err := strconv.ParseInt(fa.Unicode, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("icon %s: invalid unicode %q", name, fa.Unicode)
+		}
+		icon := minIcon{
+			Categories: categories[name],
+			Styles:     append([]string(nil), styles...),
+			Terms:      fa.Search.Terms,
+			Unicode:    int32(u),
+		}
+		sort.Strings(icon.Styles)
+		// 显示名和图标名只是大小写, 空格不同时不保存, 减小文件大小
+		if !strings.EqualFold(strings.ReplaceAll(fa.Label, " ", "-"), name) {
+			icon.Label = fa.Label
+		}
+		// 别名和其它图标名相同时以图标名为准
+		for _, alias := range fa.Aliases.Names {
+			if _, ok := faIcons[alias]; !ok {
+				icon.Aliases = append(icon.Aliases, alias)
+			}
+		}
+		icons[name] = icon
+	}
+	return icons, nil
+}
+
+// parseCategories 解析 categories.yml, 返回图标名对应的分类. 只支持 FontAwesome 使用的简单格式:
+//
+//	animals:
+//	  icons:
+//	    - cat
+//	    - dog
+//	  label: Animals
+//
+// r: categories.yml 的内容.
+func parseCategories(r io.Reader) (map[string][]string, error) {
+	categories := make(map[string][]string)
+	var category string
+	inIcons := false
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+		case line[0] != ' ': // 顶层的分类名
+			category = strings.TrimSuffix(trimmed, ":")
+			inIcons = false
+		case strings.HasPrefix(trimmed, "- "):
+			if inIcons && category != "" {
+				name := strings.Trim(strings.TrimSpace(trimmed[2:]), `'"`)
+				categories[name] = append(categories[name], category)
+			}
+		default: // 分类下的其它字段
+			inIcons = trimmed == "icons:"
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	for _, list := range categories {
+		sort.Strings(list)
+	}
+	return categories, nil
+}
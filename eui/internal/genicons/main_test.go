@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseCategories(t *testing.T) {
+	yml := `accessibility:
+  icons:
+    - accessible-icon
+    - universal-access
+  label: Accessibility
+animals:
+  icons:
+    - cat
+    - universal-access
+  label: Animals
+`
+	got, err := parseCategories(strings.NewReader(yml))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"accessible-icon":  {"accessibility"},
+		"universal-access": {"accessibility", "animals"},
+		"cat":              {"animals"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCategories() = %v, want %v", got, want)
+	}
+}
+
+func Test_convert(t *testing.T) {
+	var faIcons map[string]faIcon
+	err := json.Unmarshal([]byte(`{
+		"house": {"aliases": {"names": ["home", "home-alt"]}, "label": "House", "search": {"terms": ["abode"]}, "styles": ["solid"], "free": ["solid"], "unicode": "f015"},
+		"home-alt": {"label": "Home Alt", "styles": ["solid"], "free": ["solid"], "unicode": "f000"},
+		"pro-only": {"label": "Pro", "styles": ["solid"], "free": [], "unicode": "f001"},
+		"wpexplorer": {"label": "WPExplorer", "styles": ["brands"], "unicode": "f2de"}
+	}`), &faIcons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	icons, err := convert(faIcons, map[string][]string{"house": {"buildings"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := icons["pro-only"]; ok {
+		t.Errorf("icons without free styles should be dropped")
+	}
+	data, _ := json.Marshal(icons["house"])
+	if got := string(data); got != `{"a":["home"],"c":["buildings"],"s":["solid"],"t":["abode"],"u":61461}` {
+		t.Errorf("house = %s", got)
+	}
+	if icons["wpexplorer"].Label != "" || icons["wpexplorer"].Styles[0] != "brands" {
+		t.Errorf("wpexplorer = %+v", icons["wpexplorer"])
+	}
+}
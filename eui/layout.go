@@ -0,0 +1,296 @@
+package eui
+
+import "strings"
+
+// ContentOption 图标和文字布局的选项.
+type ContentOption struct {
+	// 图标相对文字的位置, 可使用常量: ButtonIconPosition_.
+	IconPosition int
+	// 图标和文字的间距, 只有其中一个时不使用.
+	Gap int32
+	// 文字太宽时是否自动换行. 为 false 时只在'\n'处换行, 太宽的行末尾显示省略号.
+	Wrap bool
+	// 文字是否可以超出区域的宽度. 为 true 时不限制文字宽度, 只在'\n'处换行, 也不显示省略号, Wrap 无效.
+	Overflow bool
+}
+
+// ContentLayout 图标和文字的布局结果, 由 LayoutContent 计算, 绘制函数按其中的矩形绘制.
+//   - 文字已按区域换行, 放不下的行和最后一行超出的部分已替换成省略号.
+type ContentLayout struct {
+	Icon       Rect     // 图标位置, 没有图标时宽高为 0
+	Text       Rect     // 所有行文字占用的区域
+	Lines      []string // 每一行文字
+	LineHeight int32    // 行高
+}
+
+// LineRect 返回第 i 行文字的区域.
+//
+// i: 行号, 从 0 开始.
+func (l ContentLayout) LineRect(i int) Rect {
+	top := l.Text.Top + int32(i)*l.LineHeight
+	return Rect{Left: l.Text.Left, Top: top, Right: l.Text.Right, Bottom: top + l.LineHeight}
+}
+
+// ellipsis 是文字放不下时显示的省略号.
+const ellipsis = "…"
+
+// LayoutContent 计算图标和文字在 rc 中的布局. 文字只测量一次, 图标和文字作为一个整体在 rc 中居中.
+//
+// cv: 画布, 用来测量默认字体的文字大小.
+//
+// rc: 放置图标和文字的区域.
+//
+// text: 文字, 可以包含'\n'.
+//
+// icon: 图标大小, 没有图标时为 0.
+//
+// opt: 布局选项.
+func LayoutContent(cv Canvas, rc Rect, text string, icon Size, opt ContentOption) ContentLayout {
+	gap := opt.Gap
+	if icon.CX == 0 || text == "" {
+		gap = 0
+	}
+	// 图标占去的宽度或高度, 剩下的给文字
+	maxWidth, maxHeight := rc.Width(), rc.Height()
+	if opt.IconPosition == ButtonIconPosition_Top {
+		maxHeight -= icon.CY + gap
+	} else {
+		maxWidth -= icon.CX + gap
+	}
+	if opt.Overflow {
+		maxWidth = 0
+	}
+
+	var l ContentLayout
+	var textSize Size
+	if text != "" {
+		l.Lines, l.LineHeight = breakLines(cv, text, maxWidth, maxHeight, opt.Wrap)
+		for _, line := range l.Lines {
+			if w := cv.TextSize(line, 0).CX; w > textSize.CX {
+				textSize.CX = w
+			}
+		}
+		textSize.CY = l.LineHeight * int32(len(l.Lines))
+	}
+	l.Icon, l.Text = layoutIconText(rc, icon, textSize, opt.IconPosition, gap)
+	return l
+}
+
+// breakLines 把文字分成多行, 放不下的部分替换成省略号.
+//   - 至少保留一行.
+//
+// maxWidth, maxHeight: 文字区域的最大宽高, 宽度小于 1 时不换行也不省略.
+//
+// wrap: 是否在太宽时自动换行, 优先在空格处换行.
+func breakLines(cv Canvas, text string, maxWidth, maxHeight int32, wrap bool) (lines []string, lineHeight int32) {
+	paragraphs := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for _, p := range paragraphs {
+		if h := cv.TextSize(p, 0).CY; h > lineHeight {
+			lineHeight = h
+		}
+		if !wrap || maxWidth < 1 {
+			lines = append(lines, p)
+			continue
+		}
+		lines = append(lines, wrapLine(cv, p, maxWidth)...)
+	}
+	if lineHeight < 1 {
+		lineHeight = 1
+	}
+
+	// 行数超出高度时, 最后一行加上省略号
+	maxLines := int(maxHeight / lineHeight)
+	if maxLines < 1 {
+		maxLines = 1
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] += ellipsis
+	}
+	if maxWidth > 0 {
+		for i, line := range lines {
+			lines[i] = truncateText(cv, line, maxWidth)
+		}
+	}
+	return lines, lineHeight
+}
+
+// wrapLine 把一行文字按宽度分成多行, 优先在空格处换行, 没有空格时在字符间换行, 如中文. emoji 等由多个字符组成的字不会被拆开.
+//
+// maxWidth: 最大宽度.
+func wrapLine(cv Canvas, text string, maxWidth int32) []string {
+	var lines []string
+	runes := []rune(text)
+	for len(runes) > 0 {
+		// 找出能放下的最多字符数, 至少一个
+		n := 1
+		for n < len(runes) && cv.TextSize(string(runes[:n+1]), 0).CX <= maxWidth {
+			n++
+		}
+		// 不拆开 emoji 等由多个字符组成的字
+		if i := clusterStart(runes, n); i > 0 {
+			n = i
+		} else {
+			for n < len(runes) && clusterStart(runes, n) != n {
+				n++
+			}
+		}
+		if n < len(runes) {
+			// 在最后一个空格处换行, 空格留在上一行末尾
+			for i := n - 1; i > 0; i-- {
+				if runes[i] == ' ' {
+					n = i + 1
+					break
+				}
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:n]), " "))
+		runes = runes[n:]
+	}
+	return lines
+}
+
+// truncateText 文字太宽时从末尾去掉字符并加上省略号. 已经以省略号结尾的文字只去掉省略号前面的字符. emoji 等由多个字符组成的字会整个去掉.
+//
+// maxWidth: 最大宽度.
+func truncateText(cv Canvas, text string, maxWidth int32) string {
+	if cv.TextSize(text, 0).CX <= maxWidth {
+		return text
+	}
+	runes := []rune(strings.TrimSuffix(text, ellipsis))
+	for len(runes) > 0 {
+		runes = runes[:clusterStart(runes, len(runes)-1)]
+		s := strings.TrimRight(string(runes), " ") + ellipsis
+		if cv.TextSize(s, 0).CX <= maxWidth {
+			return s
+		}
+	}
+	return ellipsis
+}
+
+// layoutIconText 计算图标和文字的位置, 图标和文字作为一个整体在 rc 中居中. 只有图标或只有文字时也居中.
+//
+// rc: 放置图标和文字的区域.
+//
+// icon: 图标大小, 没有图标时为 0.
+//
+// text: 文字大小, 没有文字时为 0.
+//
+// position: 图标位置, 可使用常量: ButtonIconPosition_.
+//
+// gap: 图标和文字的间距, 只有其中一个时不使用.
+func layoutIconText(rc Rect, icon, text Size, position int, gap int32) (rcIcon, rcText Rect) {
+	if icon.CX == 0 || text.CX == 0 {
+		gap = 0
+	}
+	if position == ButtonIconPosition_Top {
+		top := rc.Top + (rc.Height()-icon.CY-gap-text.CY)/2
+		left := rc.Left + (rc.Width()-icon.CX)/2
+		rcIcon = Rect{Left: left, Top: top, Right: left + icon.CX, Bottom: top + icon.CY}
+		left = rc.Left + (rc.Width()-text.CX)/2
+		rcText = Rect{Left: left, Top: rcIcon.Bottom + gap, Right: left + text.CX, Bottom: rcIcon.Bottom + gap + text.CY}
+		return
+	}
+
+	left := rc.Left + (rc.Width()-icon.CX-gap-text.CX)/2
+	iconLeft, textLeft := left, left+icon.CX+gap
+	if position == ButtonIconPosition_Right {
+		textLeft, iconLeft = left, left+text.CX+gap
+	}
+	top := rc.Top + (rc.Height()-icon.CY)/2
+	rcIcon = Rect{Left: iconLeft, Top: top, Right: iconLeft + icon.CX, Bottom: top + icon.CY}
+	top = rc.Top + (rc.Height()-text.CY)/2
+	rcText = Rect{Left: textLeft, Top: top, Right: textLeft + text.CX, Bottom: top + text.CY}
+	return
+}
+
+// LayoutContent 计算元素的图标和文字在 rc 中的布局, 规则见 LayoutContent 函数. 自定义的绘制函数可以用它和 DrawContent 像按钮一样绘制内容.
+//
+// rc: 放置图标和文字的区域.
+//
+// opt: 布局选项.
+func (ctx *PaintContext) LayoutContent(rc Rect, opt ContentOption) ContentLayout {
+	var icon Size
+	icon.CX, icon.CY = ctx.IconSize()
+	return LayoutContent(ctx.Canvas, rc, ctx.Text, icon, opt)
+}
+
+// DrawContent 按布局绘制元素的图标和文字, 每行文字水平居中.
+//
+// l: LayoutContent 计算的布局.
+//
+// textColor: 文字颜色.
+//
+// iconColor: 图标颜色, hImage 图标不会改变颜色.
+func (ctx *PaintContext) DrawContent(l ContentLayout, textColor, iconColor uint32) {
+	if l.Icon.Width() > 0 {
+		ctx.DrawIcon(l.Icon.Left, l.Icon.Top, iconColor)
+	}
+	if len(l.Lines) == 0 {
+		return
+	}
+	cv := ctx.Canvas
+	cv.SetBrushColor(textColor)
+	cv.SetTextAlign(TextAlign_VCenter | TextAlign_NoWrap | TextAlign_Center)
+	for i, line := range l.Lines {
+		if line != "" {
+			cv.DrawText(line, l.LineRect(i))
+		}
+	}
+}
+
+// layoutButtonGroup 计算按钮组中每个按钮的位置和与相邻按钮相接的边.
+//   - 相邻按钮重叠 1 像素, 共用一条边框.
+//   - 横排时按钮高度统一为最高按钮的高度, 竖排时宽度统一为最宽按钮的宽度.
+//
+// sizes: 每个按钮的大小.
+//
+// vertical: 是否竖排.
+func layoutButtonGroup(sizes []Size, vertical bool) (rects []Rect, joinSides []int, total Size) {
+	for _, size := range sizes {
+		if size.CX > total.CX {
+			total.CX = size.CX
+		}
+		if size.CY > total.CY {
+			total.CY = size.CY
+		}
+	}
+	// 与后一个, 前一个按钮相接的边
+	nextSide, prevSide := side_Right, side_Left
+	if vertical {
+		nextSide, prevSide = side_Bottom, side_Top
+		total.CY = 0
+	} else {
+		total.CX = 0
+	}
+
+	var pos int32
+	for i, size := range sizes {
+		if i > 0 {
+			pos-- // 与前一个按钮重叠 1 像素
+		}
+		var rc Rect
+		if vertical {
+			rc = Rect{Top: pos, Right: total.CX, Bottom: pos + size.CY}
+			pos = rc.Bottom
+		} else {
+			rc = Rect{Left: pos, Right: pos + size.CX, Bottom: total.CY}
+			pos = rc.Right
+		}
+		var sides int
+		if i > 0 {
+			sides |= prevSide
+		}
+		if i < len(sizes)-1 {
+			sides |= nextSide
+		}
+		rects = append(rects, rc)
+		joinSides = append(joinSides, sides)
+	}
+	if vertical {
+		total.CY = pos
+	} else {
+		total.CX = pos
+	}
+	return rects, joinSides, total
+}
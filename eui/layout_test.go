@@ -0,0 +1,156 @@
+package eui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_layoutIconText(t *testing.T) {
+	rc := Rect{Right: 100, Bottom: 40}
+	icon, text := Size{CX: 16, CY: 16}, Size{CX: 30, CY: 20}
+	tests := []struct {
+		name           string
+		icon, text     Size
+		position       int
+		rcIcon, rcText Rect
+	}{
+		{"left", icon, text, ButtonIconPosition_Left, Rect{25, 12, 41, 28}, Rect{45, 10, 75, 30}},
+		{"right", icon, text, ButtonIconPosition_Right, Rect{59, 12, 75, 28}, Rect{25, 10, 55, 30}},
+		{"top", icon, text, ButtonIconPosition_Top, Rect{42, 0, 58, 16}, Rect{35, 20, 65, 40}},
+		{"icon only", icon, Size{}, ButtonIconPosition_Right, Rect{42, 12, 58, 28}, Rect{42, 20, 42, 20}},
+		{"text only", Size{}, text, ButtonIconPosition_Left, Rect{35, 20, 35, 20}, Rect{35, 10, 65, 30}},
+	}
+	for _, tt := range tests {
+		rcIcon, rcText := layoutIconText(rc, tt.icon, tt.text, tt.position, 4)
+		if rcIcon != tt.rcIcon || rcText != tt.rcText {
+			t.Errorf("%s: got %v %v, want %v %v", tt.name, rcIcon, rcText, tt.rcIcon, tt.rcText)
+		}
+	}
+}
+
+func Test_LayoutContent(t *testing.T) {
+	// 默认字号 12: 英文字符宽 6, 中文和省略号宽 12, 行高 16
+	cv := NewImageCanvas(60, 40)
+	rc := Rect{Right: 60, Bottom: 40}
+	tests := []struct {
+		name  string
+		text  string
+		wrap  bool
+		lines []string
+	}{
+		{"fit", "hello", false, []string{"hello"}},
+		{"ellipsis", "hello world foo", false, []string{"hello wo…"}},
+		{"newline", "a\r\nb", false, []string{"a", "b"}},
+		{"wrap at space", "hello world foo", true, []string{"hello", "world foo"}},
+		{"wrap cjk", "一二三四五六七八九十百", true, []string{"一二三四五", "六七八九…"}},
+		{"too many lines", "a\nb\nc", false, []string{"a", "b…"}},
+	}
+	for _, tt := range tests {
+		l := LayoutContent(cv, rc, tt.text, Size{}, ContentOption{Wrap: tt.wrap})
+		if !reflect.DeepEqual(l.Lines, tt.lines) {
+			t.Errorf("%s: lines = %q, want %q", tt.name, l.Lines, tt.lines)
+		}
+		if l.LineHeight != 16 || l.Text.Height() != 16*int32(len(l.Lines)) {
+			t.Errorf("%s: line height %d, text rect %v", tt.name, l.LineHeight, l.Text)
+		}
+	}
+
+	// 图标占去的宽度不能给文字用
+	l := LayoutContent(cv, rc, "hello world", Size{CX: 16, CY: 16}, ContentOption{Gap: 4})
+	if want := []string{"hell…"}; !reflect.DeepEqual(l.Lines, want) || l.Icon.Left != 2 || l.Text.Right != 58 {
+		t.Errorf("with icon: lines = %q, icon %v, text %v", l.Lines, l.Icon, l.Text)
+	}
+
+	// 可以超出区域时不换行也不省略, 仍然居中
+	l = LayoutContent(cv, rc, "hello world foo", Size{}, ContentOption{Wrap: true, Overflow: true})
+	if want := []string{"hello world foo"}; !reflect.DeepEqual(l.Lines, want) || l.Text.Left != -15 || l.Text.Right != 75 {
+		t.Errorf("overflow: lines = %q, text %v", l.Lines, l.Text)
+	}
+}
+
+func Test_breakLines_noMaxWidth(t *testing.T) {
+	// 宽度小于 1 时不限制宽度, 行数超出时只在最后一行后面加省略号
+	cv := NewImageCanvas(60, 40)
+	lines, lineHeight := breakLines(cv, "hello\nworld\nfoo", 0, 32, true)
+	if want := []string{"hello", "world…"}; !reflect.DeepEqual(lines, want) || lineHeight != 16 {
+		t.Errorf("lines = %q, line height %d, want %q", lines, lineHeight, want)
+	}
+}
+
+func Test_utf16Len(t *testing.T) {
+	tests := []struct {
+		text string
+		want int32
+	}{
+		{"", 0},
+		{"OK", 2},
+		{"确定", 2},
+		{"确定OK", 4},
+		{"👍", 2},
+		{"👍🏽", 4},
+		{"好👍a", 4},
+	}
+	for _, tt := range tests {
+		if got := utf16Len(tt.text); got != tt.want {
+			t.Errorf("utf16Len(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func Test_LayoutContent_mixed(t *testing.T) {
+	// 中文和 emoji 宽 12, 英文宽 6, 肤色和零宽连接符宽 0
+	cv := NewImageCanvas(100, 40)
+	rc := Rect{Right: 100, Bottom: 40}
+	tests := []struct {
+		name      string
+		text      string
+		icon      Size
+		lines     []string
+		textLeft  int32
+		textRight int32
+		iconLeft  int32
+	}{
+		{"cjk latin", "确定OK", Size{}, []string{"确定OK"}, 32, 68, 32},
+		{"emoji", "确定OK👍", Size{}, []string{"确定OK👍"}, 26, 74, 26},
+		{"skin tone", "好👍🏽a", Size{}, []string{"好👍🏽a"}, 35, 65, 35},
+		{"with icon", "确定OK👍", Size{CX: 16, CY: 16}, []string{"确定OK👍"}, 36, 84, 16},
+		{"ellipsis mixed", "确定确定确定确定👍🏽OK", Size{}, []string{"确定确定确定确…"}, 2, 98, 2},
+	}
+	for _, tt := range tests {
+		l := LayoutContent(cv, rc, tt.text, tt.icon, ContentOption{Gap: 4})
+		if !reflect.DeepEqual(l.Lines, tt.lines) {
+			t.Errorf("%s: lines = %q, want %q", tt.name, l.Lines, tt.lines)
+		}
+		if l.Text.Left != tt.textLeft || l.Text.Right != tt.textRight || l.Icon.Left != tt.iconLeft {
+			t.Errorf("%s: text %v icon %v, want text left %d right %d icon left %d", tt.name, l.Text, l.Icon, tt.textLeft, tt.textRight, tt.iconLeft)
+		}
+	}
+
+	// 换行时不拆开 emoji
+	lines := wrapLine(cv, "👨‍👩👍🏽", 12)
+	if want := []string{"👨‍👩", "👍🏽"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("wrapLine = %q, want %q", lines, want)
+	}
+}
+
+func Test_layoutButtonGroup(t *testing.T) {
+	sizes := []Size{{40, 32}, {50, 40}, {40, 32}}
+	rects, sides, total := layoutButtonGroup(sizes, false)
+	wantRects := []Rect{{0, 0, 40, 40}, {39, 0, 89, 40}, {88, 0, 128, 40}}
+	wantSides := []int{side_Right, side_Left | side_Right, side_Left}
+	if !reflect.DeepEqual(rects, wantRects) || !reflect.DeepEqual(sides, wantSides) || total != (Size{128, 40}) {
+		t.Errorf("horizontal: got %v %v %v", rects, sides, total)
+	}
+
+	rects, sides, total = layoutButtonGroup(sizes, true)
+	wantRects = []Rect{{0, 0, 50, 32}, {0, 31, 50, 71}, {0, 70, 50, 102}}
+	wantSides = []int{side_Bottom, side_Top | side_Bottom, side_Top}
+	if !reflect.DeepEqual(rects, wantRects) || !reflect.DeepEqual(sides, wantSides) || total != (Size{50, 102}) {
+		t.Errorf("vertical: got %v %v %v", rects, sides, total)
+	}
+
+	rects, sides, _ = layoutButtonGroup(sizes[:1], false)
+	if sides[0] != 0 || rects[0] != (Rect{0, 0, 40, 32}) {
+		t.Errorf("single: got %v %v", rects, sides)
+	}
+}
@@ -1,8 +1,9 @@
+//go:build windows
+
 package eui
 
 import (
 	"strconv"
-	"strings"
 
 	"github.com/twgh/xcgui/xc"
 	"github.com/twgh/xcgui/xcc"
@@ -13,13 +14,27 @@ type objBase struct {
 	hFontAwesomeMap map[string]int // FontAwesome字体句柄
 	H               int            // 句柄.
 	dpi             int32          // 窗口dpi
+	eui             *Elementui     // 所属的 Elementui 对象
+}
+
+// getTheme 获取元素所用的主题.
+func (o *objBase) getTheme() *Theme {
+	if o.eui != nil && o.eui.theme != nil {
+		return o.eui.theme
+	}
+	return defaultTheme
+}
+
+// state 获取元素的状态. 元素不是用 Elementui 创建的或已经销毁时返回 nil.
+func (o *objBase) state() *eleState {
+	return getState(o.H)
 }
 
 // ClearIcon 清除掉已设置的 Font Awesome 图标, hsvg 和 himage.
 func (o *objBase) ClearIcon() *objBase {
-	xc.XC_SetProperty(o.H, "element-icon-hsvg", "")
-	xc.XC_SetProperty(o.H, "element-icon-himage", "")
-	xc.XC_SetProperty(o.H, "element-icon-fa", "")
+	if s := o.state(); s != nil {
+		s.clearIcon()
+	}
 	return o
 }
 
@@ -28,16 +43,18 @@ func (o *objBase) ClearIcon() *objBase {
 // hSvg: 炫彩 svg 句柄.
 func (o *objBase) SetHSvg(hSvg int) *objBase {
 	o.ClearIcon()
-	if hSvg > 0 && xc.XC_IsHXCGUI(hSvg, xcc.XC_SVG) {
-		xc.XC_SetProperty(o.H, "element-icon-hsvg", strconv.Itoa(hSvg))
+	if s := o.state(); s != nil && hSvg > 0 && xc.XC_IsHXCGUI(hSvg, xcc.XC_SVG) {
+		s.hSvg = hSvg
 	}
 	return o
 }
 
 // GetHSvg 获取已设置的炫彩 svg 句柄.
 func (o *objBase) GetHSvg() int {
-	hSvg, _ := strconv.Atoi(xc.XC_GetProperty(o.H, "element-icon-hsvg"))
-	return hSvg
+	if s := o.state(); s != nil {
+		return s.hSvg
+	}
+	return 0
 }
 
 // SetHImage 设置炫彩图片句柄.
@@ -45,16 +62,18 @@ func (o *objBase) GetHSvg() int {
 // hImage: 炫彩图片句柄.
 func (o *objBase) SetHImage(hImage int) *objBase {
 	o.ClearIcon()
-	if hImage > 0 && xc.XC_IsHXCGUI(hImage, xcc.XC_IMAGE_FRAME) {
-		xc.XC_SetProperty(o.H, "element-icon-himage", strconv.Itoa(hImage))
+	if s := o.state(); s != nil && hImage > 0 && xc.XC_IsHXCGUI(hImage, xcc.XC_IMAGE_FRAME) {
+		s.hImage = hImage
 	}
 	return o
 }
 
 // GetHImage 获取已设置的炫彩图片句柄.
 func (o *objBase) GetHImage() int {
-	hImage, _ := strconv.Atoi(xc.XC_GetProperty(o.H, "element-icon-himage"))
-	return hImage
+	if s := o.state(); s != nil {
+		return s.hImage
+	}
+	return 0
 }
 
 // SetIconName 设置 Font Wesome 图标名.
@@ -62,32 +81,14 @@ func (o *objBase) GetHImage() int {
 // iconName: Font Awesome 图标名.
 //   - 如'fa-solid fa-paw', 前面是风格, 后面是图标名, 用空格分开, 其中风格可省略, 没有风格时会自动根据'fa-solid', 'fa-brands', 'fa-regular'的顺序尝试添加风格.
 //   - 图标大全: https://fa6.dashgame.com, 在网页里点导航栏图标, 然后点免费, 可筛选出 2000+ 免费图标, 点击图标会复制完整风格+图标名到剪贴板, 可直接使用. 内置 FontAwesome 版本为 6.6.0
+//   - 也可以是 Elementui.RegisterIconFont 注册的图标, 如'acme-solid acme-logo'.
+//   - FontAwesome 5 的旧图标名也能使用, 如'fa-home'会找到'fa-house', 'fa-search'会找到'fa-magnifying-glass'.
 func (o *objBase) SetIconName(iconName string) *objBase {
-	// 删首尾空
-	iconName = strings.TrimSpace(iconName)
 	var iconFaStr, fontType string
-	// 判断 IconName 是否存在, 如不存在就尝试加上所有风格前缀, 有就使用
-	var iconUnicode int32
-	var ok bool
-	iconName2 := iconName
-	styles := []string{"fa-solid ", "fa-brands ", "fa-regular "}
-	for i := -1; i < len(styles); i++ {
-		if i > -1 {
-			iconName2 = styles[i] + iconName
-		}
-		if iconUnicode, ok = fontAwesomemMap[iconName2]; ok {
-			iconName = iconName2
-			break
-		}
-	}
-	if iconUnicode > 0 {
-		iconFaStr = string(iconUnicode)
-		// 得到字体类型
-		fontType = iconName
-		index := strings.Index(fontType, " ")
-		if index != -1 {
-			fontType = fontType[:index]
-		}
+	// 从图标目录中查找, 没有风格时根据'fa-solid', 'fa-brands', 'fa-regular'的顺序选择风格
+	if icon, style, ok := Icons.Resolve(iconName); ok {
+		iconFaStr = icon.Char()
+		fontType = style
 	}
 	setIconFa(o, iconFaStr, fontType)
 	return o
@@ -95,50 +96,35 @@ func (o *objBase) SetIconName(iconName string) *objBase {
 
 // GetIconName 获取已设置的 Font Awesome 图标名.
 func (o *objBase) GetIconName() string {
-	return xc.XC_GetProperty(o.H, "element-icon-fa")
+	if s := o.state(); s != nil {
+		return s.iconFa
+	}
+	return ""
 }
 
 // SetIconHex 设置 Font Awesome 图标对应的 Unicode 码点十六进制文本.
 //
 // iconHex: Font Wesome 图标对应的 Unicode 码点十六进制文本, 如'f1b0'相当于'fa-solid fa-paw'.
-func (o *objBase) SetIconHex(iconHex string) *objBase {
+//
+// style: 优先使用的风格, 如'fa-regular', 可不填. 图标没有这个风格或不填时按'fa-solid', 'fa-brands', 'fa-regular'的顺序选择.
+func (o *objBase) SetIconHex(iconHex string, style ...string) *objBase {
 	iconInt, _ := strconv.ParseInt(iconHex, 16, 32)
-	iconUnicode := int32(iconInt)
-	iconFaStr := string(iconUnicode)
-	var fontType string
-	// 遍历 map 找出图标 name, 得到字体类型
-	for name, value := range fontAwesomemMap {
-		if value == iconUnicode {
-			fontType = name
-			index := strings.Index(fontType, " ")
-			if index != -1 {
-				fontType = fontType[:index]
-			}
-			break
-		}
-	}
-	setIconFa(o, iconFaStr, fontType)
-	return o
+	return o.SetIconUnicode(int32(iconInt), style...)
 }
 
 // SetIconUnicode 设置 Font Awesome 图标对应的 Unicode 码点十进制数字.
 //
 // iconUnicode: Font Awesome 图标对应的 Unicode 码点十进制数字, 如 61872 相当于'fa-solid fa-paw'.
-func (o *objBase) SetIconUnicode(iconUnicode int32) *objBase {
-	iconFaStr := string(iconUnicode)
-	var fontType string
-	// 遍历 map 找出图标 name, 得到字体类型
-	for name, value := range fontAwesomemMap {
-		if value == iconUnicode {
-			fontType = name
-			index := strings.Index(fontType, " ")
-			if index != -1 {
-				fontType = fontType[:index]
-			}
-			break
-		}
+//
+// style: 优先使用的风格, 如'fa-regular', 可不填. 图标没有这个风格或不填时按'fa-solid', 'fa-brands', 'fa-regular'的顺序选择.
+func (o *objBase) SetIconUnicode(iconUnicode int32, style ...string) *objBase {
+	var prefer string
+	if len(style) > 0 {
+		prefer = style[0]
 	}
-	setIconFa(o, iconFaStr, fontType)
+	// 从码点索引中得到字体类型
+	fontType := Icons.StyleOf(iconUnicode, prefer)
+	setIconFa(o, string(iconUnicode), fontType)
 	return o
 }
 
@@ -146,16 +132,119 @@ func (o *objBase) SetIconUnicode(iconUnicode int32) *objBase {
 //
 // iconFaStr: Font Awesome 图标字符串.
 //
-// fontType: 字体类型, 可为'fa-solid', 'fa-brands', 'fa-regular'.
+// fontType: 字体类型, 可为'fa-solid', 'fa-brands', 'fa-regular', 或 RegisterIconFont 注册的风格.
 func setIconFa(o *objBase, iconFaStr, fontType string) *objBase {
 	o.ClearIcon()
-	xc.XC_SetProperty(o.H, "element-icon-fa", iconFaStr)
-	if iconFaStr != "" { // 确定字体句柄和字体显示大小
-		hFontAwesome := o.hFontAwesomeMap[fontType]
-		xc.XC_SetProperty(o.H, "element-hfontawesome", strconv.Itoa(hFontAwesome))
-		var hFontAwesomeShowSize xc.SIZE
-		xc.XC_GetTextShowSize(iconFaStr, 1, hFontAwesome, &hFontAwesomeShowSize)
-		xc.XC_SetProperty(o.H, "element-hfontawesome-showsize-cx", xc.Itoa(hFontAwesomeShowSize.CX))
+	s := o.state()
+	if s == nil {
+		return o
+	}
+	s.iconFa = iconFaStr
+	s.iconStyle = fontType
+	updateIconFont(o)
+	return o
+}
+
+// 根据图标的风格和大小确定字体句柄和字体显示大小.
+//
+// o: 对象基类.
+func updateIconFont(o *objBase) {
+	s := o.state()
+	if s == nil || s.iconFa == "" {
+		return
+	}
+	if o.eui != nil {
+		s.hFontAwesome = o.eui.getIconFont(s.iconStyle, s.iconSize)
+	} else {
+		s.hFontAwesome = o.hFontAwesomeMap[s.iconStyle]
+	}
+	var hFontAwesomeShowSize xc.SIZE
+	xc.XC_GetTextShowSize(s.iconFa, utf16Len(s.iconFa), s.hFontAwesome, &hFontAwesomeShowSize)
+	s.iconFaCx = hFontAwesomeShowSize.CX
+	s.iconFaCy = hFontAwesomeShowSize.CY
+}
+
+// newFaIcon 创建元素自带功能用到的图标, 大小与元素的图标大小相同. 找不到图标时返回空图标.
+//
+// name: 图标名, 如'fa-regular fa-circle-xmark'.
+func (o *objBase) newFaIcon(name string) faIcon {
+	icon, style, ok := Icons.Resolve(name)
+	if !ok {
+		return faIcon{}
+	}
+	fi := faIcon{text: icon.Char()}
+	if o.eui != nil {
+		var iconSize int32
+		if s := o.state(); s != nil {
+			iconSize = s.iconSize
+		}
+		fi.hFont = o.eui.getIconFont(style, iconSize)
+	} else {
+		fi.hFont = o.hFontAwesomeMap[style]
+	}
+	var size xc.SIZE
+	xc.XC_GetTextShowSize(fi.text, utf16Len(fi.text), fi.hFont, &size)
+	fi.cx, fi.cy = size.CX, size.CY
+	return fi
+}
+
+// SetIconSize 设置 Font Awesome 图标的大小, 同一个 Elementui 对象创建的元素共用相同大小的字体. 不影响 hsvg 和 himage.
+//   - 可以在设置图标之前或之后调用, 之后再设置的图标也使用这个大小.
+//
+// size: 图标字体大小, 单位与 NewElementui 的 fontSize 相同. 小于 1 时使用 fontSize.
+func (o *objBase) SetIconSize(size int32) *objBase {
+	if size < 0 {
+		size = 0
+	}
+	if s := o.state(); s != nil {
+		s.iconSize = size
+		updateIconFont(o)
 	}
 	return o
 }
+
+// GetIconSize 获取已设置的图标大小, 没有设置时返回 0, 表示使用 NewElementui 的 fontSize.
+func (o *objBase) GetIconSize() int32 {
+	if s := o.state(); s != nil {
+		return s.iconSize
+	}
+	return 0
+}
+
+// SetIconColor 设置图标颜色, 对 Font Awesome 图标和 hsvg 有效, himage 不会改变颜色.
+//   - 元素禁用时仍使用默认颜色.
+//
+// color: xc.RGBA 颜色值, 为 0 时使用默认颜色: 按钮是文字颜色, 编辑框是占位文字颜色或焦点颜色.
+func (o *objBase) SetIconColor(color uint32) *objBase {
+	if s := o.state(); s != nil {
+		s.iconColor = color
+	}
+	return o
+}
+
+// GetIconColor 获取已设置的图标颜色, 没有设置时返回 0.
+func (o *objBase) GetIconColor() uint32 {
+	if s := o.state(); s != nil {
+		return s.iconColor
+	}
+	return 0
+}
+
+// SetPainter 设置元素的绘制函数名, 绘制函数需先使用 RegisterPainter 注册.
+//   - 按钮调用 SetStyle 时会把绘制函数名改回内置的.
+//
+// name: 绘制函数名.
+func (o *objBase) SetPainter(name string) *objBase {
+	if s := o.state(); s != nil {
+		s.painter = name
+	}
+	return o
+}
+
+// GetPainterName 获取元素的绘制函数名.
+func (o *objBase) GetPainterName() string {
+	if s := o.state(); s != nil {
+		return s.painter
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package eui
+
+// Painter 绘制函数. 元素绘制时会根据元素设置的绘制函数名找到并调用.
+type Painter func(ctx *PaintContext)
+
+// PaintContext 绘制上下文, 存放绘制函数需要的信息.
+//   - 绘制函数应通过 Canvas 绘制, 元素的文本和状态也都从这里取, 这样绘制函数不依赖炫彩, 可以用 ImageCanvas 在任何系统上测试.
+type PaintContext struct {
+	HEle   int    // 元素句柄, 用 ImageCanvas 绘制时为 0
+	HDraw  int    // 炫彩图形句柄, 用 ImageCanvas 绘制时为 0
+	Canvas Canvas // 画布
+	Width  int32  // 元素宽度
+	Height int32  // 元素高度
+	Theme  *Theme // 元素所用的主题
+
+	Text   string // 元素文本, 按钮是按钮文本, 其它元素为空
+	State  int    // 按钮状态, 可使用常量: ButtonState_, 其它元素为 0
+	Focus  bool   // 元素是否拥有焦点
+	Enable bool   // 元素是否启用
+
+	// 是否拦截元素原本的绘制, 默认为 true. 设为 false 时炫彩会接着绘制元素原本的样子.
+	Handled bool
+	// 元素的文本颜色, 绘制函数可设置它, 不为 0 时会在绘制后设置为元素的文本颜色. 编辑框用它来改变输入文字的颜色.
+	TextColor uint32
+
+	state stater // 元素的状态
+}
+
+// Round 返回元素的圆角大小, 已按 dpi 缩放. 四个角不同时返回最大的.
+func (ctx *PaintContext) Round() int32 {
+	return ctx.state.base().round
+}
+
+// Corners 返回元素四个角的圆角大小, 已按 dpi 缩放. 可配合 Canvas.DrawRoundRectEx 使用.
+func (ctx *PaintContext) Corners() Corners {
+	return ctx.state.base().corners()
+}
+
+// IsMouseStay 判断鼠标是否停留在元素上.
+func (ctx *PaintContext) IsMouseStay() bool {
+	return ctx.state.base().mouseStay
+}
+
+// HasIcon 判断元素是否设置了图标, 包括 Font Awesome 图标, hsvg 和 himage.
+func (ctx *PaintContext) HasIcon() bool {
+	s := ctx.state.base()
+	return s.hSvg > 0 || s.hImage > 0 || s.iconFa != ""
+}
+
+// IconSize 返回元素图标的显示大小, 没有图标时返回 0.
+func (ctx *PaintContext) IconSize() (cx, cy int32) {
+	s := ctx.state.base()
+	if size, ok := ctx.Canvas.SvgSize(s.hSvg); s.hSvg > 0 && ok {
+		return size.CX, size.CY
+	} else if size, ok := ctx.Canvas.ImageSize(s.hImage); s.hImage > 0 && ok {
+		return size.CX, size.CY
+	} else if s.iconFa != "" {
+		return s.iconFaCx, s.iconFaCy
+	}
+	return 0, 0
+}
+
+// IconColor 返回元素图标要使用的颜色. 元素设置了图标颜色并且是启用状态时返回设置的颜色, 否则返回 def.
+//
+// def: 绘制函数默认使用的图标颜色, 如按钮的文字颜色.
+func (ctx *PaintContext) IconColor(def uint32) uint32 {
+	if c := ctx.state.base().iconColor; c != 0 && ctx.Enable {
+		return c
+	}
+	return def
+}
+
+// DrawIcon 在指定位置绘制元素的图标.
+//   - hImage 图标不会改变颜色.
+//
+// x, y: 图标左上角坐标.
+//
+// color: 图标颜色.
+func (ctx *PaintContext) DrawIcon(x, y int32, color uint32) {
+	s := ctx.state.base()
+	cv := ctx.Canvas
+	if _, ok := cv.SvgSize(s.hSvg); s.hSvg > 0 && ok {
+		cv.DrawSvg(s.hSvg, x, y, color)
+	} else if _, ok := cv.ImageSize(s.hImage); s.hImage > 0 && ok {
+		cv.DrawImage(s.hImage, x, y)
+	} else if s.iconFa != "" {
+		rc := Rect{Left: x, Top: y, Right: x + s.iconFaCx, Bottom: y + s.iconFaCy}
+		cv.SetFont(s.hFontAwesome)
+		cv.SetBrushColor(color)
+		cv.SetTextAlign(TextAlign_VCenter | TextAlign_NoWrap | TextAlign_Center)
+		cv.DrawText(s.iconFa, rc)
+		cv.SetFont(0)
+	}
+}
+
+// 按钮状态, 与 xcc.Button_State_ 相同, 用于 PaintContext.State.
+
+const (
+	ButtonState_Leave   = iota // 离开
+	ButtonState_Stay           // 停留
+	ButtonState_Down           // 按下
+	ButtonState_Check          // 选中
+	ButtonState_Disable        // 禁用
+)
+
+// painterMap 存放绘制函数, 键是绘制函数名.
+var painterMap = map[string]Painter{
+	"onDrawButton_Default":     onDrawButton_Default,
+	"onDrawButton_Color":       onDrawButton_Color,
+	"onDrawButton_Text":        onDrawButton_Text,
+	"onDrawButton_Color_Plain": onDrawButton_Color_Plain,
+	"onDrawEdit":               onDrawEdit,
+	"onDrawEditSlot":           onDrawEditSlot,
+	"onDrawIconPicker":         onDrawIconPicker,
+	"onDrawTextarea":           onDrawTextarea,
+	"onDrawScrollBar":          onDrawScrollBar,
+	"onDrawScrollThumb":        onDrawScrollThumb,
+	"onDrawAutocomplete":       onDrawAutocomplete,
+}
+
+// RegisterPainter 注册绘制函数. 元素调用 SetPainter 设置了绘制函数名后, 绘制时就会调用这个函数.
+//   - 内置的绘制函数名有: onDrawButton_Default, onDrawButton_Color, onDrawButton_Text, onDrawButton_Color_Plain, onDrawEdit, onDrawEditSlot, onDrawIconPicker, onDrawTextarea, onDrawScrollBar, onDrawScrollThumb, onDrawAutocomplete. 使用相同的名字注册会替换掉内置的绘制函数.
+//   - 只能在 UI 线程中调用.
+//
+// name: 绘制函数名.
+//
+// painter: 绘制函数, 为 nil 时删除这个绘制函数.
+func RegisterPainter(name string, painter Painter) {
+	if painter == nil {
+		delete(painterMap, name)
+		return
+	}
+	painterMap[name] = painter
+}
+
+// GetPainter 获取已注册的绘制函数, 没有时返回 nil. 可用来在自己的绘制函数中先调用内置的绘制函数.
+//
+// name: 绘制函数名.
+func GetPainter(name string) Painter {
+	return painterMap[name]
+}
@@ -0,0 +1,121 @@
+package eui
+
+import "math"
+
+// Palette 色阶, 是由一个基础颜色按 Element UI 的规则混合白色或黑色计算出来的一组颜色.
+//   - 颜色值的排列与 xc.RGBA 的返回值相同.
+//   - 下面注释中的白色和黑色是 NewPalette 的情况, 暗色主题中浅色阶混入的是背景颜色, 深色阶混入的是白色.
+type Palette struct {
+	Base uint32 // 基础颜色
+
+	Light1 uint32 // 混入 10% 白色
+	Light2 uint32 // 混入 20% 白色, 彩色按钮悬浮时的背景颜色
+	Light3 uint32 // 混入 30% 白色
+	Light4 uint32 // 混入 40% 白色, 禁用的朴素按钮的文字颜色
+	Light5 uint32 // 混入 50% 白色, 禁用的彩色按钮的背景颜色
+	Light6 uint32 // 混入 60% 白色, 朴素按钮的边框颜色
+	Light7 uint32 // 混入 70% 白色, 默认按钮悬浮时的边框颜色
+	Light8 uint32 // 混入 80% 白色, 禁用的朴素按钮的边框颜色
+	Light9 uint32 // 混入 90% 白色, 朴素按钮的背景颜色
+
+	Dark2 uint32 // 混入 10% 黑色, 按钮按下时的颜色
+}
+
+// NewPalette 根据基础颜色计算出色阶, 浅色阶混入白色, 深色阶混入黑色.
+//
+// color: 基础颜色, xc.RGBA 的返回值. 透明度会保留到每个色阶中.
+func NewPalette(color uint32) Palette {
+	return NewPaletteWith(color, color|0x00FFFFFF, color&0xFF000000)
+}
+
+// NewPaletteWith 根据基础颜色计算出色阶, 可指定浅色阶和深色阶混入的颜色.
+//   - 暗色主题中浅色阶混入的是背景颜色, 深色阶混入的是白色.
+//
+// color: 基础颜色, xc.RGBA 的返回值.
+//
+// light: 浅色阶混入的颜色.
+//
+// dark: 深色阶混入的颜色.
+func NewPaletteWith(color, light, dark uint32) Palette {
+	return Palette{
+		Base:   color,
+		Light1: MixColor(light, color, 0.1),
+		Light2: MixColor(light, color, 0.2),
+		Light3: MixColor(light, color, 0.3),
+		Light4: MixColor(light, color, 0.4),
+		Light5: MixColor(light, color, 0.5),
+		Light6: MixColor(light, color, 0.6),
+		Light7: MixColor(light, color, 0.7),
+		Light8: MixColor(light, color, 0.8),
+		Light9: MixColor(light, color, 0.9),
+		Dark2:  MixColor(dark, color, 0.1),
+	}
+}
+
+// MixColor 按权重混合两个颜色, 与 sass 的 mix 函数相同.
+//
+// color1: 颜色1, xc.RGBA 的返回值.
+//
+// color2: 颜色2, xc.RGBA 的返回值.
+//
+// weight: color1 所占的比例, 0 到 1 之间.
+func MixColor(color1, color2 uint32, weight float64) uint32 {
+	if weight < 0 {
+		weight = 0
+	} else if weight > 1 {
+		weight = 1
+	}
+	var color uint32
+	for shift := uint(0); shift < 32; shift += 8 {
+		c1 := float64(color1 >> shift & 0xFF)
+		c2 := float64(color2 >> shift & 0xFF)
+		color |= uint32(math.Round(c1*weight+c2*(1-weight))) << shift
+	}
+	return color
+}
+
+// NewButtonColors 根据一个颜色计算出彩色按钮在各个状态下的颜色, 效果与内置的彩色按钮样式一致.
+//   - 可以把返回值放到 ButtonColors 中, 键用一个不与 ButtonStyle_ 常量重复的数字, 然后把这个数字当做按钮样式来用.
+//
+// color: 按钮颜色, xc.RGBA 的返回值.
+func (t *Theme) NewButtonColors(color uint32) ButtonColors {
+	p := t.newPalette(color)
+	white := t.ColorWhite
+	return ButtonColors{
+		Bg:   [5]uint32{p.Base, p.Light2, p.Dark2, 0, p.Light5},
+		Text: [5]uint32{white, white, white, 0, white},
+
+		PlainBg:     [5]uint32{p.Light9, p.Base, p.Dark2, 0, p.Light9},
+		PlainBorder: [5]uint32{p.Light6, p.Base, p.Dark2, 0, p.Light8},
+		PlainText:   [5]uint32{p.Base, white, white, 0, p.Light4},
+	}
+}
+
+// GenerateButtonColors 根据主题中的 ColorPrimary 等颜色重新计算内置按钮样式的颜色.
+//   - 修改了主题颜色后调用本函数, 按钮的悬浮, 按下, 禁用等颜色才会跟着改变.
+//   - 会覆盖 ButtonColors 中内置样式的颜色, 自定义样式的颜色不受影响.
+func (t *Theme) GenerateButtonColors() *Theme {
+	if t.ButtonColors == nil {
+		t.ButtonColors = make(map[int]ButtonColors)
+	}
+	p := t.newPalette(t.ColorPrimary)
+	bg := t.BackgroundColor
+	t.ButtonColors[ButtonStyle_Default] = ButtonColors{
+		Bg:     [5]uint32{bg, p.Light9, p.Light9, 0, bg},
+		Border: [5]uint32{t.BorderColorBase, p.Light7, p.Dark2, 0, t.BorderColorLighter},
+		Text:   [5]uint32{t.ColorTextRegular, p.Base, p.Dark2, 0, t.ColorTextPlaceholder},
+
+		PlainBg:     [5]uint32{bg, bg, bg, 0, bg},
+		PlainBorder: [5]uint32{t.BorderColorBase, p.Light7, p.Dark2, 0, t.BorderColorLighter},
+		PlainText:   [5]uint32{t.ColorTextRegular, p.Base, p.Dark2, 0, t.ColorTextPlaceholder},
+	}
+	t.ButtonColors[ButtonStyle_Primary] = t.NewButtonColors(t.ColorPrimary)
+	t.ButtonColors[ButtonStyle_Success] = t.NewButtonColors(t.ColorSuccess)
+	t.ButtonColors[ButtonStyle_Info] = t.NewButtonColors(t.ColorInfo)
+	t.ButtonColors[ButtonStyle_Warning] = t.NewButtonColors(t.ColorWarning)
+	t.ButtonColors[ButtonStyle_Danger] = t.NewButtonColors(t.ColorDanger)
+	t.ButtonColors[ButtonStyle_Text] = ButtonColors{
+		Text: [5]uint32{p.Base, p.Light2, p.Dark2, 0, t.ColorTextPlaceholder},
+	}
+	return t
+}
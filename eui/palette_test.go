@@ -0,0 +1,36 @@
+package eui
+
+import "testing"
+
+func Test_NewPalette(t *testing.T) {
+	// 主要颜色的色阶应与 Element UI 的按钮颜色一致
+	p := NewPalette(rgba(64, 158, 255, 255))
+	want := map[string][2]uint32{
+		"Light2": {p.Light2, rgba(102, 177, 255, 255)},
+		"Light4": {p.Light4, rgba(140, 197, 255, 255)},
+		"Light5": {p.Light5, rgba(160, 207, 255, 255)},
+		"Light6": {p.Light6, rgba(179, 216, 255, 255)},
+		"Light7": {p.Light7, rgba(198, 226, 255, 255)},
+		"Light8": {p.Light8, rgba(217, 236, 255, 255)},
+		"Light9": {p.Light9, rgba(236, 245, 255, 255)},
+		"Dark2":  {p.Dark2, rgba(58, 142, 230, 255)},
+	}
+	for name, v := range want {
+		if v[0] != v[1] {
+			t.Errorf("%s = %x, want %x", name, v[0], v[1])
+		}
+	}
+}
+
+func Test_NewButtonColors(t *testing.T) {
+	// 危险按钮的颜色应与 Element UI 一致
+	c := DefaultTheme().ButtonColors[ButtonStyle_Danger]
+	wantBg := [5]uint32{rgba(245, 108, 108, 255), rgba(247, 137, 137, 255), rgba(221, 97, 97, 255), 0, rgba(250, 182, 182, 255)}
+	if c.Bg != wantBg {
+		t.Errorf("Bg = %x, want %x", c.Bg, wantBg)
+	}
+	wantPlainBorder := [5]uint32{rgba(251, 196, 196, 255), rgba(245, 108, 108, 255), rgba(221, 97, 97, 255), 0, rgba(253, 226, 226, 255)}
+	if c.PlainBorder != wantPlainBorder {
+		t.Errorf("PlainBorder = %x, want %x", c.PlainBorder, wantPlainBorder)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !eui_subset
+
+package eui
+
+import _ "embed"
+
+var (
+	//go:embed res/fa-solid-900.ttf
+	fontAwesomeSolid []byte
+	//go:embed res/fa-brands-400.ttf
+	fontAwesomeBrands []byte
+	//go:embed res/fa-regular-400.ttf
+	fontAwesomeRegular []byte
+	// FontAwesome 图标的名字, 码点, 风格, 别名, 搜索词和分类, 更新 FontAwesome 后使用 go generate 重新生成.
+	//go:embed res/icons.min.json
+	fontAwesomeJson []byte
+)
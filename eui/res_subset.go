@@ -0,0 +1,11 @@
+//go:build eui_subset
+
+package eui
+
+// 使用 eui_subset 构建标签时不内置字体和图标数据, 需由 cmd/euisubset 生成的代码调用 LoadIconSubset 加载.
+var (
+	fontAwesomeSolid   []byte
+	fontAwesomeBrands  []byte
+	fontAwesomeRegular []byte
+	fontAwesomeJson    []byte
+)
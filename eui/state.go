@@ -0,0 +1,260 @@
+package eui
+
+import "time"
+
+// eleState 元素的状态, 是所有元素共有的部分.
+//   - 状态直接存在 Go 这边, 以元素句柄为键, 绘制时不需要通过 XC_GetProperty 取出字符串再解析.
+type eleState struct {
+	eui     *Elementui // 所属的 Elementui 对象
+	painter string     // 绘制函数名, 是 RegisterPainter 注册时的名字
+	round   int32      // 圆角大小, 已按 dpi 缩放, 四个角不同时是最大的
+	roundEx *Corners   // 四个角各自的圆角大小, 已按 dpi 缩放, 为 nil 时四个角都是 round
+
+	hSvg         int    // 炫彩 svg 句柄
+	hImage       int    // 炫彩图片句柄
+	iconFa       string // Font Awesome 图标字符
+	hFontAwesome int    // Font Awesome 图标所用的炫彩字体句柄
+	iconFaCx     int32  // Font Awesome 图标的显示宽度
+	iconFaCy     int32  // Font Awesome 图标的显示高度
+	iconStyle    string // Font Awesome 图标的风格, 改变图标大小时用来重新取字体
+	iconSize     int32  // 图标字体大小, 为 0 时使用 NewElementui 的 fontSize
+	iconColor    uint32 // 图标颜色, 为 0 时使用绘制函数默认的颜色
+
+	mouseStay bool // 鼠标是否停留在元素上
+}
+
+// base 返回元素共有的状态.
+func (s *eleState) base() *eleState {
+	return s
+}
+
+// setRound 设置四个角相同的圆角大小.
+//
+// round: 已按 dpi 缩放的圆角大小.
+func (s *eleState) setRound(round int32) {
+	s.round = round
+	s.roundEx = nil
+}
+
+// setRoundEx 设置四个角各自的圆角大小.
+//
+// corners: 已按 dpi 缩放的圆角大小.
+func (s *eleState) setRoundEx(corners Corners) {
+	s.round = corners.max()
+	s.roundEx = &corners
+}
+
+// corners 返回四个角的圆角大小.
+func (s *eleState) corners() Corners {
+	if s.roundEx != nil {
+		return *s.roundEx
+	}
+	return uniformCorners(s.round)
+}
+
+// clearIcon 清除掉已设置的图标. 图标大小和颜色是元素的设置, 不会清除.
+func (s *eleState) clearIcon() {
+	s.hSvg = 0
+	s.hImage = 0
+	s.iconFa = ""
+	s.hFontAwesome = 0
+	s.iconFaCx = 0
+	s.iconFaCy = 0
+	s.iconStyle = ""
+}
+
+// buttonState 按钮的状态.
+type buttonState struct {
+	eleState
+	style  int  // 按钮样式
+	plain  bool // 是否朴素按钮
+	circle bool // 是否圆形按钮
+
+	iconPosition int   // 图标位置, 是 ButtonIconPosition_ 常量
+	iconGap      int32 // 图标和文字的间距, 已按 dpi 缩放, 为 0 时使用 Theme.SpaceIconText
+	textWrap     bool  // 文字太长时是否自动换行, 否则显示为一行
+	joinSides    int   // 在按钮组中与相邻按钮相接的边, 这些边上的角是直角, 是 side_ 常量的组合
+
+	// 各个按钮状态下的颜色, 顺序: Leave, Stay, Down, Check, Disable
+	bgColors, textColors, borderColors [5]uint32
+
+	hAni    int    // 加载中动画序列句柄
+	oldText string // 加载前的文本
+	oldHSvg int    // 加载前的炫彩 svg 句柄
+}
+
+// faIcon 是元素自带功能用到的 Font Awesome 图标, 如编辑框的清空图标.
+type faIcon struct {
+	text   string // 图标字符
+	hFont  int    // 炫彩字体句柄
+	cx, cy int32  // 显示大小
+}
+
+// editSlot 编辑框的前置或后置元素.
+type editSlot struct {
+	hEle      int   // 元素句柄
+	cx        int32 // 元素宽度, 已按 dpi 缩放, 没有元素时为 0
+	ownBorder bool  // 元素是否自己绘制与输入部分相接的边框, 此时与输入部分重叠 1 像素
+}
+
+// editState 编辑框的状态.
+type editState struct {
+	eleState
+	spaceLeft int32 // 直角时图标和边框的间距, 已按 dpi 缩放
+	iconRight bool  // 图标是否在右边
+	autoColor bool  // 图标颜色是否根据焦点颜色自动改变
+
+	hasText   bool   // 是否有内容, 绘制前更新
+	toolHover int    // 鼠标停留的功能图标, 是 editTool_ 常量
+	clearable bool   // 是否可清空
+	clearIcon faIcon // 清空图标
+	rcClear   Rect   // 清空图标的位置, 绘制时更新, 没有显示时为空
+	onClear   func() // 点击清空图标清空内容后调用的函数
+
+	showPassword    bool      // 是否是可切换显示的密码框
+	passwordVisible bool      // 密码是否显示出来
+	passwordIcons   [2]faIcon // 隐藏密码时和显示密码时的切换图标
+	rcPassword      Rect      // 切换图标的位置, 绘制时更新, 没有显示时为空
+
+	slots     [2]editSlot // 前置和后置元素, 下标是 editSlot_ 常量
+	slotStay  bool        // 鼠标是否停留在前置或后置元素上
+	slotFocus bool        // 前置或后置元素是否拥有焦点
+
+	suggest *autocompleteState // 输入建议, 不是带输入建议的编辑框时为 nil
+
+	autosize  bool  // 多行输入框是否根据内容自动调整高度
+	minRows   int32 // 自动调整高度时的最少行数, < 1 时不限制
+	maxRows   int32 // 自动调整高度时的最多行数, < 1 时不限制
+	paddingY  int32 // 文字和上下边框的间距, 与 SetBorderSize 的单位相同, 单行编辑框为 0
+	gripSize  int32 // 多行输入框右下角拖动调整高度的区域大小, 已按 dpi 缩放, 为 0 时不能拖动
+	resizing  bool  // 是否正在拖动调整高度
+	resizeOff int32 // 开始拖动时鼠标与底边的距离
+}
+
+// iconPickerState 图标选择器的状态.
+type iconPickerState struct {
+	eleState
+	query    string // 搜索内容
+	style    string // 只显示拥有这个风格的图标, 为空时显示全部
+	category string // 只显示这个分类的图标, 为空时显示全部
+
+	icons      []Icon         // 过滤后的图标
+	fonts      map[string]int // 风格对应的炫彩字体句柄, 字体大小是 iconSize
+	cellWidth  int32          // 格子宽度, 已按 dpi 缩放
+	cellHeight int32          // 格子高度, 已按 dpi 缩放
+	padding    int32          // 格子和边框的间距, 已按 dpi 缩放
+	scrollBar  int32          // 滚动条宽度, 已按 dpi 缩放
+	scrollY    int32          // 垂直滚动位置
+	hover      int            // 鼠标停留的图标在 icons 中的下标, 没有时为 -1
+	selected   string         // 选中的带前缀的图标名, 如'fa-paw'
+
+	onSelect func(icon Icon, style string) // 选中图标时调用
+}
+
+// autocompleteState 输入建议弹出框的状态. 同时记录在编辑框的状态中, 弹出框创建后也以弹出框句柄为键记录.
+type autocompleteState struct {
+	eleState
+	hEdit  int  // 所属的编辑框句柄
+	hPopup int  // 弹出框句柄, 第一次显示时创建
+	show   bool // 弹出框是否显示
+
+	query      string       // 获取建议时编辑框的内容
+	items      []Suggestion // 输入建议
+	hover      int          // 鼠标停留或用上下键选中的建议下标, 没有时为 -1
+	scrollY    int32        // 垂直滚动位置
+	itemHeight int32        // 每条建议的高度, 已按 dpi 缩放
+	padding    int32        // 建议和上下边框的间距, 已按 dpi 缩放
+	scrollBar  int32        // 滚动条宽度, 已按 dpi 缩放
+	maxRows    int32        // 最多显示的行数, 再多就滚动
+
+	fetch          func(query string, cb func([]Suggestion)) // 获取建议的函数
+	debounce       time.Duration                             // 停止输入多久后获取建议
+	triggerOnFocus bool                                      // 获得焦点时是否获取建议
+	timer          *time.Timer                               // 防抖计时器
+	seq            int                                       // 每次获取建议加 1, 用来丢弃过时的结果
+	selecting      bool                                      // 正在把选中的建议填入编辑框, 此时内容改变不获取建议
+
+	itemPainter SuggestionPainter     // 建议的绘制函数, 为 nil 时使用默认的
+	onSelect    func(item Suggestion) // 选中建议后调用的函数
+}
+
+// stater 是各种元素状态都实现了的接口.
+type stater interface {
+	base() *eleState
+}
+
+// stateMap 存放元素句柄对应的状态.
+var stateMap = make(map[int]stater)
+
+// setState 记录元素的状态.
+//
+// hEle: 元素句柄.
+//
+// s: 元素状态.
+func setState(hEle int, s stater) {
+	stateMap[hEle] = s
+}
+
+// deleteState 删除元素的状态.
+//
+// hEle: 元素句柄.
+func deleteState(hEle int) {
+	delete(stateMap, hEle)
+}
+
+// getState 获取元素共有的状态, 没有记录时返回 nil.
+//
+// hEle: 元素句柄.
+func getState(hEle int) *eleState {
+	if s, ok := stateMap[hEle]; ok {
+		return s.base()
+	}
+	return nil
+}
+
+// getButtonState 获取按钮的状态, 没有记录或不是按钮时返回 nil.
+//
+// hEle: 元素句柄.
+func getButtonState(hEle int) *buttonState {
+	s, _ := stateMap[hEle].(*buttonState)
+	return s
+}
+
+// getEditState 获取编辑框的状态, 没有记录或不是编辑框时返回 nil.
+//
+// hEle: 元素句柄.
+func getEditState(hEle int) *editState {
+	s, _ := stateMap[hEle].(*editState)
+	return s
+}
+
+// getIconPickerState 获取图标选择器的状态, 没有记录或不是图标选择器时返回 nil.
+//
+// hEle: 元素句柄.
+func getIconPickerState(hEle int) *iconPickerState {
+	s, _ := stateMap[hEle].(*iconPickerState)
+	return s
+}
+
+// getAutocompleteState 获取输入建议的状态, 没有记录或不是带输入建议的编辑框时返回 nil.
+//
+// hEle: 编辑框句柄或弹出框句柄.
+func getAutocompleteState(hEle int) *autocompleteState {
+	switch s := stateMap[hEle].(type) {
+	case *autocompleteState:
+		return s
+	case *editState:
+		return s.suggest
+	}
+	return nil
+}
+
+// getTheme 获取元素所用的主题, 找不到时返回默认主题.
+//
+// hEle: 元素句柄.
+func getTheme(hEle int) *Theme {
+	if s := getState(hEle); s != nil && s.eui != nil && s.eui.theme != nil {
+		return s.eui.theme
+	}
+	return defaultTheme
+}
@@ -0,0 +1,40 @@
+package eui
+
+import "testing"
+
+func Test_stateMap(t *testing.T) {
+	const hBtn, hEdit = 1001, 1002
+	defer deleteState(hBtn)
+	defer deleteState(hEdit)
+
+	e := &Elementui{theme: DarkTheme()}
+	setState(hBtn, &buttonState{eleState: eleState{eui: e, round: 4}, style: ButtonStyle_Primary})
+	setState(hEdit, &editState{eleState: eleState{eui: e}, iconRight: true})
+
+	if s := getButtonState(hBtn); s == nil || s.style != ButtonStyle_Primary || s.round != 4 {
+		t.Errorf("getButtonState(hBtn) = %+v", s)
+	}
+	if s := getEditState(hEdit); s == nil || !s.iconRight {
+		t.Errorf("getEditState(hEdit) = %+v", s)
+	}
+	// 类型不对时返回 nil
+	if s := getEditState(hBtn); s != nil {
+		t.Errorf("getEditState(hBtn) = %+v, want nil", s)
+	}
+	// 共有状态可以直接修改
+	getState(hBtn).iconFa = "a"
+	if s := getButtonState(hBtn); s.iconFa != "a" {
+		t.Errorf("iconFa = %q, want %q", s.iconFa, "a")
+	}
+	if theme := getTheme(hEdit); theme != e.theme {
+		t.Errorf("getTheme(hEdit) is not the Elementui theme")
+	}
+
+	deleteState(hBtn)
+	if s := getState(hBtn); s != nil {
+		t.Errorf("getState after delete = %+v, want nil", s)
+	}
+	if theme := getTheme(hBtn); theme != defaultTheme {
+		t.Errorf("getTheme of unknown element is not the default theme")
+	}
+}
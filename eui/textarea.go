@@ -0,0 +1,270 @@
+//go:build windows
+
+package eui
+
+import (
+	"github.com/twgh/xcgui/widget"
+	"github.com/twgh/xcgui/xc"
+	"github.com/twgh/xcgui/xcc"
+)
+
+// Textarea 是 Elementui 风格的多行输入框, 继承 Edit, 可使用编辑框的方法.
+//   - 文字和上下边框的间距是 Theme.PaddingTextarea, 左右边框与编辑框相同.
+//   - 垂直滚动条按主题绘制, 内容超出时才显示.
+type Textarea struct {
+	Edit
+}
+
+// CreateTextarea 创建多行输入框.
+//   - 高度由行数决定, 自动调整高度时由内容的行数决定.
+//   - 内部除了编辑框注册的事件外, 还注册了编辑框内容改变事件, 鼠标左键弹起事件.
+//
+// hParent: 父元素或父窗口句柄.
+//
+// opts: TextareaOption 多行输入框选项, 可不填.
+func (e *Elementui) CreateTextarea(hParent int, opts ...TextareaOption) *Textarea {
+	var opt TextareaOption
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Width < 1 {
+		opt.Width = 180
+	}
+	if opt.Rows < 1 {
+		opt.Rows = textareaRows
+	}
+
+	edit := updateEdit(e, false, hParent, 0, EditOption{
+		DefaultText: opt.DefaultText,
+		X:           opt.X,
+		Y:           opt.Y,
+		Width:       opt.Width,
+		Height:      1,
+	})
+	t := &Textarea{Edit: *edit}
+	s := t.edState()
+	s.painter = "onDrawTextarea"
+	s.paddingY = e.theme.PaddingTextarea
+	s.minRows = opt.MinRows
+	s.maxRows = opt.MaxRows
+
+	// 启用多行和自动换行
+	t.EnableMultiLine(true)
+	xc.XEdit_EnableAutoWrap(t.H, true)
+	t.EnableAutoShowScrollBar(true)
+	styleScrollBarV(e, t.H)
+	t.updateBorderSize()
+	t.EnableResizable(opt.IsResizable)
+	t.SetRows(opt.Rows)
+	t.EnableAutosize(opt.IsAutosize)
+
+	// 注册编辑框内容改变事件, 用于自动调整高度
+	t.Event_EDIT_CHANGED1(onTextareaChanged)
+	// 注册元素鼠标左键按下事件, 用于开始拖动调整高度
+	t.Event_LBUTTONDOWN1(onTextareaLButtonDown)
+	// 注册元素鼠标移动事件, 用于拖动调整高度
+	t.Event_MOUSEMOVE1(onTextareaMouseMove)
+	// 注册元素鼠标左键弹起事件, 用于结束拖动
+	t.Event_LBUTTONUP1(onTextareaLButtonUp)
+	return t
+}
+
+// SetRows 按行数设置高度. 自动调整高度时无效.
+//
+// rows: 行数, < 1 时为 1.
+func (t *Textarea) SetRows(rows int32) *Textarea {
+	if s := t.edState(); s != nil && !s.autosize {
+		setTextareaRows(t.H, textareaAutoRows(rows, 1, 0))
+	}
+	return t
+}
+
+// EnableAutosize 设置是否根据内容的行数自动调整高度, 行数在 SetAutosizeRows 设置的范围内.
+//   - 拖动调整的高度在内容改变后会被重新计算.
+//
+// isAutosize: 是否自动调整高度.
+func (t *Textarea) EnableAutosize(isAutosize bool) *Textarea {
+	if s := t.edState(); s != nil {
+		s.autosize = isAutosize
+	}
+	autosizeTextarea(t.H)
+	return t
+}
+
+// IsAutosize 判断是否根据内容自动调整高度.
+func (t *Textarea) IsAutosize() bool {
+	if s := t.edState(); s != nil {
+		return s.autosize
+	}
+	return false
+}
+
+// SetAutosizeRows 设置自动调整高度时的行数范围. 超过最多行数时显示滚动条.
+//
+// minRows: 最少行数, < 1 时不限制.
+//
+// maxRows: 最多行数, < 1 时不限制.
+func (t *Textarea) SetAutosizeRows(minRows, maxRows int32) *Textarea {
+	if s := t.edState(); s != nil {
+		s.minRows = minRows
+		s.maxRows = maxRows
+		autosizeTextarea(t.H)
+	}
+	return t
+}
+
+// GetAutosizeRows 获取自动调整高度时的行数范围.
+func (t *Textarea) GetAutosizeRows() (minRows, maxRows int32) {
+	if s := t.edState(); s != nil {
+		return s.minRows, s.maxRows
+	}
+	return 0, 0
+}
+
+// EnableResizable 设置是否可以拖动右下角调整高度.
+//
+// isResizable: 是否可调整高度.
+func (t *Textarea) EnableResizable(isResizable bool) *Textarea {
+	if s := t.edState(); s != nil {
+		s.gripSize = 0
+		if isResizable {
+			s.gripSize = textareaGripSize * t.dpi / 96
+		}
+	}
+	t.Redraw(false)
+	return t
+}
+
+// IsResizable 判断是否可以拖动右下角调整高度.
+func (t *Textarea) IsResizable() bool {
+	if s := t.edState(); s != nil {
+		return s.gripSize > 0
+	}
+	return false
+}
+
+// TextareaOption 多行输入框选项.
+type TextareaOption struct {
+	// 当无内容时显示的文本.
+	DefaultText string
+
+	// 高度由行数决定, 所以没有 Height. Width 为 0 时是 180.
+	X, Y, Width int32
+
+	// 显示的行数, 默认 2. 自动调整高度时无效.
+	Rows int32
+	// 是否根据内容的行数自动调整高度.
+	IsAutosize bool
+	// 自动调整高度时的最少行数, < 1 时不限制.
+	MinRows int32
+	// 自动调整高度时的最多行数, < 1 时不限制. 超过时显示滚动条.
+	MaxRows int32
+
+	// 是否可以拖动右下角调整高度.
+	IsResizable bool
+}
+
+// styleScrollBarV 按主题绘制滚动视图的垂直滚动条: 隐藏上下按钮, 轨道透明, 滑块是半透明的圆角矩形.
+//
+// hView: 滚动视图句柄, 如编辑框.
+func styleScrollBarV(e *Elementui, hView int) {
+	xc.XSView_SetScrollBarSize(hView, scrollBarSize*e.dpi/96)
+	hBar := xc.XSView_GetScrollBarV(hView)
+	if hBar == 0 {
+		return
+	}
+	xc.XSBar_ShowButton(hBar, false)
+	for _, item := range []struct {
+		h       int
+		painter string
+	}{
+		{hBar, "onDrawScrollBar"},
+		{xc.XSBar_GetButtonSlider(hBar), "onDrawScrollThumb"},
+	} {
+		if item.h == 0 {
+			continue
+		}
+		setState(item.h, &eleState{eui: e, painter: item.painter})
+		xc.XEle_EnableBkTransparent(item.h, true)
+		ele := widget.NewElementByHandle(item.h)
+		ele.Event_PAINT1(onDrawEle)
+		ele.Event_DESTROY1(onDestroyEle)
+	}
+}
+
+// textareaRowHeight 返回多行输入框的行高.
+func textareaRowHeight(hEle int) int32 {
+	if h := xc.XEdit_GetRowHeight(hEle); h > 0 {
+		return h
+	}
+	return 20
+}
+
+// setTextareaRows 按行数设置多行输入框的高度, 然后重绘.
+//
+// rows: 行数.
+func setTextareaRows(hEle int, rows int32) {
+	s := getEditState(hEle)
+	if s == nil {
+		return
+	}
+	height := textareaHeight(rows, textareaRowHeight(hEle), s.paddingY)
+	if height != xc.XEle_GetHeight(hEle) {
+		xc.XEle_SetSize(hEle, xc.XEle_GetWidth(hEle), height, true, xcc.AdjustLayout_All, 0)
+	}
+}
+
+// autosizeTextarea 自动调整高度时按内容的行数设置多行输入框的高度.
+func autosizeTextarea(hEle int) {
+	if s := getEditState(hEle); s != nil && s.autosize {
+		setTextareaRows(hEle, textareaAutoRows(xc.XEdit_GetRowCount(hEle), s.minRows, s.maxRows))
+	}
+}
+
+// 多行输入框内容改变事件, 自动调整高度
+func onTextareaChanged(hEle int, pbHandled *bool) int {
+	autosizeTextarea(hEle)
+	return 0
+}
+
+// 多行输入框鼠标左键按下事件, 按在右下角时开始拖动调整高度
+func onTextareaLButtonDown(hEle int, nFlags uint32, pPt *xc.POINT, pbHandled *bool) int {
+	s := getEditState(hEle)
+	if s == nil {
+		return 0
+	}
+	height := xc.XEle_GetHeight(hEle)
+	if !s.gripRect(xc.XEle_GetWidth(hEle), height).contains(pPt.X, pPt.Y) {
+		return 0
+	}
+	s.resizing = true
+	s.resizeOff = height - pPt.Y
+	xc.XEle_SetCapture(hEle, true)
+	*pbHandled = true
+	return 0
+}
+
+// 多行输入框鼠标移动事件, 拖动时调整高度, 最少一行
+func onTextareaMouseMove(hEle int, nFlags uint32, pPt *xc.POINT, pbHandled *bool) int {
+	s := getEditState(hEle)
+	if s == nil || !s.resizing {
+		return 0
+	}
+	height := pPt.Y + s.resizeOff
+	if min := textareaHeight(1, textareaRowHeight(hEle), s.paddingY); height < min {
+		height = min
+	}
+	xc.XEle_SetSize(hEle, xc.XEle_GetWidth(hEle), height, true, xcc.AdjustLayout_All, 0)
+	*pbHandled = true
+	return 0
+}
+
+// 多行输入框鼠标左键弹起事件, 结束拖动
+func onTextareaLButtonUp(hEle int, nFlags uint32, pPt *xc.POINT, pbHandled *bool) int {
+	if s := getEditState(hEle); s != nil && s.resizing {
+		s.resizing = false
+		xc.XEle_SetCapture(hEle, false)
+		*pbHandled = true
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package eui
+
+const (
+	textareaRows     = 2  // 多行输入框默认显示的行数
+	textareaGripDot  = 2  // 拖动调整高度的角上每个点的大小
+	textareaGripSize = 14 // 拖动调整高度的角的大小
+	scrollBarSize    = 6  // 滚动条宽度, 未按 dpi 缩放
+)
+
+// textareaAutoRows 计算自动调整高度时要显示的行数.
+//
+// lineCount: 内容的行数.
+//
+// minRows, maxRows: 最少, 最多行数, < 1 时不限制.
+func textareaAutoRows(lineCount, minRows, maxRows int32) int32 {
+	rows := lineCount
+	if maxRows > 0 && rows > maxRows {
+		rows = maxRows
+	}
+	if minRows > 0 && rows < minRows {
+		rows = minRows
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// textareaHeight 计算多行输入框显示 rows 行时的高度, 包括上下边框.
+//
+// rows: 行数.
+//
+// rowHeight: 行高.
+//
+// padding: 文字和上下边框的间距.
+func textareaHeight(rows, rowHeight, padding int32) int32 {
+	return rows*rowHeight + padding*2
+}
+
+// gripRect 返回多行输入框右下角拖动调整高度的区域, 不能调整时为空.
+//
+// width, height: 多行输入框宽高.
+func (s *editState) gripRect(width, height int32) Rect {
+	if s.gripSize < 1 {
+		return Rect{}
+	}
+	return Rect{Left: width - s.gripSize, Top: height - s.gripSize, Right: width, Bottom: height}
+}
+
+// 多行输入框绘制事件. 边框和背景与编辑框相同, 可调整高度时在右下角绘制三角形排列的点.
+func onDrawTextarea(ctx *PaintContext) {
+	s, ok := ctx.state.(*editState)
+	if !ok {
+		return
+	}
+	cv := ctx.Canvas
+	rc := Rect{Right: ctx.Width, Bottom: ctx.Height}
+	borderColor, bgColor := editColors(ctx, s)
+
+	corners := s.corners()
+	cv.SetBrushColor(borderColor)
+	cv.DrawRoundRectEx(rc, corners)
+	cv.SetBrushColor(bgColor)
+	cv.FillRoundRectEx(Rect{Left: 1, Top: 1, Right: rc.Right - 1, Bottom: rc.Bottom - 1}, corners)
+
+	grip := s.gripRect(ctx.Width, ctx.Height)
+	if grip.Right == 0 {
+		return
+	}
+	// 点从右下角开始, 每行比下面一行少一个点
+	dot := s.gripSize * textareaGripDot / textareaGripSize
+	if dot < 1 {
+		dot = 1
+	}
+	step := dot * 2
+	right := grip.Right - 1 - dot
+	bottom := grip.Bottom - 1 - dot
+	cv.SetBrushColor(ctx.Theme.ColorTextPlaceholder)
+	for row := int32(0); row < 3; row++ {
+		for col := int32(0); col < 3-row; col++ {
+			x := right - col*step
+			y := bottom - row*step
+			cv.FillRoundRect(Rect{Left: x - dot, Top: y - dot, Right: x, Bottom: y}, 0)
+		}
+	}
+}
+
+// 滚动条绘制事件. 不绘制轨道, 让元素的背景透出来.
+func onDrawScrollBar(ctx *PaintContext) {}
+
+// 滚动条滑块绘制事件. 绘制半透明的圆角滑块, 鼠标停留和按下时颜色变深.
+func onDrawScrollThumb(ctx *PaintContext) {
+	alpha := uint32(0x4d)
+	if ctx.State == ButtonState_Stay || ctx.State == ButtonState_Down {
+		alpha = 0x80
+	}
+	rc := Rect{Right: ctx.Width, Bottom: ctx.Height}
+	round := ctx.Width / 2
+	if ctx.Height < ctx.Width { // 横向滚动条
+		round = ctx.Height / 2
+	}
+	ctx.Canvas.SetBrushColor(ctx.Theme.ColorTextSecondary&0x00FFFFFF | alpha<<24)
+	ctx.Canvas.FillRoundRect(rc, round)
+}
@@ -0,0 +1,39 @@
+package eui
+
+import "testing"
+
+func Test_textareaAutoRows(t *testing.T) {
+	tests := []struct {
+		lineCount, minRows, maxRows, want int32
+	}{
+		{0, 0, 0, 1},  // 没有内容也显示一行
+		{5, 0, 0, 5},  // 不限制
+		{1, 2, 4, 2},  // 少于最少行数
+		{3, 2, 4, 3},  // 在范围内
+		{9, 2, 4, 4},  // 超过最多行数, 显示滚动条
+		{9, 2, -1, 9}, // 最多行数 < 1 时不限制
+	}
+	for _, tt := range tests {
+		if got := textareaAutoRows(tt.lineCount, tt.minRows, tt.maxRows); got != tt.want {
+			t.Errorf("textareaAutoRows(%d, %d, %d) = %d, want %d", tt.lineCount, tt.minRows, tt.maxRows, got, tt.want)
+		}
+	}
+	if got := textareaHeight(3, 20, 5); got != 70 {
+		t.Errorf("textareaHeight(3, 20, 5) = %d, want 70", got)
+	}
+}
+
+func Test_editState_gripRect(t *testing.T) {
+	s := &editState{}
+	if rc := s.gripRect(180, 80); rc != (Rect{}) || rc.contains(179, 79) {
+		t.Errorf("not resizable: got %v", rc)
+	}
+	s.gripSize = 14
+	rc := s.gripRect(180, 80)
+	if rc != (Rect{166, 66, 180, 80}) {
+		t.Errorf("resizable: got %v", rc)
+	}
+	if !rc.contains(179, 79) || rc.contains(180, 79) || rc.contains(165, 79) {
+		t.Errorf("contains: wrong result for %v", rc)
+	}
+}
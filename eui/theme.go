@@ -0,0 +1,168 @@
+package eui
+
+// Theme 主题, 存放 Elementui 的颜色, 圆角和间距.
+//   - 可使用 DefaultTheme 获取默认主题后修改其中的字段, 再传给 NewElementui, 这样不用修改本库即可换成自己的品牌色.
+//   - 修改了 ColorPrimary 等颜色后需调用 GenerateButtonColors 重新计算按钮颜色.
+//   - 颜色值都是 xc.RGBA 的返回值.
+type Theme struct {
+	// 主要颜色, 默认 #409EFF.
+	ColorPrimary uint32
+	// 成功颜色, 默认 #67C23A.
+	ColorSuccess uint32
+	// 警告颜色, 默认 #E6A23C.
+	ColorWarning uint32
+	// 危险颜色, 默认 #F56C6C.
+	ColorDanger uint32
+	// 信息颜色, 默认 #909399.
+	ColorInfo uint32
+	// 白色, 默认 #FFFFFF. 用于彩色按钮的文字.
+	ColorWhite uint32
+
+	// 主要文字颜色, 默认 #303133.
+	ColorTextPrimary uint32
+	// 常规文字颜色, 默认 #606266.
+	ColorTextRegular uint32
+	// 次要文字颜色, 默认 #909399.
+	ColorTextSecondary uint32
+	// 占位文字颜色, 默认 #C0C4CC.
+	ColorTextPlaceholder uint32
+
+	// 一级边框颜色, 默认 #DCDFE6.
+	BorderColorBase uint32
+	// 二级边框颜色, 默认 #E4E7ED.
+	BorderColorLight uint32
+	// 三级边框颜色, 默认 #EBEEF5.
+	BorderColorLighter uint32
+	// 四级边框颜色, 默认 #F2F6FC.
+	BorderColorExtraLight uint32
+
+	// 元素的背景颜色, 默认 #FFFFFF. 也是色阶中浅色阶混入的颜色.
+	BackgroundColor uint32
+	// 基础背景颜色, 默认 #F5F7FA. 用于禁用的编辑框.
+	BackgroundColorBase uint32
+
+	// 是否为暗色主题. 暗色主题的色阶中深色阶混入的是白色, 否则是黑色.
+	IsDark bool
+
+	// 按钮不同样式的颜色, 键是 ButtonStyle_ 常量, 也可以添加自定义的样式.
+	//  - 内置样式的颜色由 GenerateButtonColors 根据主题颜色计算得出.
+	//  - 自定义样式的颜色可使用 NewButtonColors 计算.
+	ButtonColors map[int]ButtonColors
+
+	// 基础圆角, 默认 4.
+	BorderRadiusBase int32
+	// 小圆角, 默认 2.
+	BorderRadiusSmall int32
+	// 大圆角, 默认 20.
+	BorderRadiusRound int32
+
+	// 图标和文字之间的间距, 默认 4.
+	SpaceIconText int32
+	// 编辑框直角时图标和边框的间距, 默认 4.
+	SpaceInputIcon int32
+	// 编辑框文字和边框的间距, 默认 15.
+	PaddingInput int32
+	// 编辑框有图标的一侧文字和边框的间距, 默认 29.
+	PaddingInputIcon int32
+	// 编辑框前置/后置文字或图标和左右边框的间距, 默认 20.
+	PaddingInputSlot int32
+	// 多行输入框文字和上下边框的间距, 默认 5.
+	PaddingTextarea int32
+	// 自动换行的按钮文字和左右边框的最小间距, 文字太长时在这里换行, 默认 10.
+	PaddingButton int32
+}
+
+// ButtonColors 按钮某个样式在各个状态下的颜色.
+//   - 数组顺序: Leave, Stay, Down, Check, Disable
+type ButtonColors struct {
+	Bg     [5]uint32 // 背景颜色
+	Border [5]uint32 // 边框颜色, 彩色按钮没有边框
+	Text   [5]uint32 // 文字颜色
+
+	PlainBg     [5]uint32 // 朴素按钮的背景颜色
+	PlainBorder [5]uint32 // 朴素按钮的边框颜色
+	PlainText   [5]uint32 // 朴素按钮的文字颜色
+}
+
+// DefaultTheme 返回 Element UI 的默认主题. 每次调用都会返回一个新的对象, 可随意修改.
+func DefaultTheme() *Theme {
+	t := &Theme{
+		ColorPrimary: rgba(64, 158, 255, 255),
+		ColorSuccess: rgba(103, 194, 58, 255),
+		ColorWarning: rgba(230, 162, 60, 255),
+		ColorDanger:  rgba(245, 108, 108, 255),
+		ColorInfo:    rgba(144, 147, 153, 255),
+		ColorWhite:   rgba(255, 255, 255, 255),
+
+		ColorTextPrimary:     rgba(48, 49, 51, 255),
+		ColorTextRegular:     rgba(96, 98, 102, 255),
+		ColorTextSecondary:   rgba(144, 147, 153, 255),
+		ColorTextPlaceholder: rgba(192, 196, 204, 255),
+
+		BorderColorBase:       rgba(220, 223, 230, 255),
+		BorderColorLight:      rgba(228, 231, 237, 255),
+		BorderColorLighter:    rgba(235, 238, 245, 255),
+		BorderColorExtraLight: rgba(242, 246, 252, 255),
+
+		BackgroundColor:     rgba(255, 255, 255, 255),
+		BackgroundColorBase: rgba(245, 247, 250, 255),
+
+		BorderRadiusBase:  4,
+		BorderRadiusSmall: 2,
+		BorderRadiusRound: 20,
+
+		SpaceIconText:    4,
+		SpaceInputIcon:   4,
+		PaddingInput:     15,
+		PaddingInputIcon: 29,
+		PaddingInputSlot: 20,
+		PaddingTextarea:  5,
+		PaddingButton:    10,
+	}
+	t.GenerateButtonColors()
+	return t
+}
+
+// DarkTheme 返回暗色主题, 颜色取自 Element Plus 的暗黑模式. 每次调用都会返回一个新的对象, 可随意修改.
+func DarkTheme() *Theme {
+	t := DefaultTheme()
+	t.IsDark = true
+
+	t.ColorTextPrimary = rgba(229, 234, 243, 255)
+	t.ColorTextRegular = rgba(207, 211, 220, 255)
+	t.ColorTextSecondary = rgba(163, 166, 173, 255)
+	t.ColorTextPlaceholder = rgba(141, 144, 149, 255)
+
+	t.BorderColorBase = rgba(76, 77, 79, 255)
+	t.BorderColorLight = rgba(65, 66, 67, 255)
+	t.BorderColorLighter = rgba(54, 54, 55, 255)
+	t.BorderColorExtraLight = rgba(43, 43, 44, 255)
+
+	t.BackgroundColor = rgba(20, 20, 20, 255)
+	t.BackgroundColorBase = rgba(38, 39, 39, 255)
+
+	t.GenerateButtonColors()
+	return t
+}
+
+// newPalette 根据主题的背景颜色计算出色阶.
+//
+// color: 基础颜色.
+func (t *Theme) newPalette(color uint32) Palette {
+	dark := color & 0xFF000000
+	if t.IsDark {
+		dark |= 0x00FFFFFF
+	}
+	return NewPaletteWith(color, t.BackgroundColor, dark)
+}
+
+// defaultTheme 是未传入主题时使用的默认主题.
+var defaultTheme = DefaultTheme()
+
+// 主题模式.
+
+const (
+	ThemeMode_Light  = iota // 亮色
+	ThemeMode_Dark          // 暗色
+	ThemeMode_System        // 跟随系统, 系统是否为暗色由 Elementui.SetSystemDark 告知
+)
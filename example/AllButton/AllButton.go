@@ -1,3 +1,5 @@
+//go:build windows
+
 // 所有按钮例子
 package main
 
@@ -0,0 +1,142 @@
+//go:build windows
+
+// 图标浏览器例子, 可搜索, 按风格和分类筛选, 点击图标后可复制图标名, 十六进制, 十进制
+package main
+
+import (
+	"strconv"
+
+	"github.com/twgh/xc-elementui/eui"
+	"github.com/twgh/xcgui/app"
+	"github.com/twgh/xcgui/font"
+	"github.com/twgh/xcgui/widget"
+	"github.com/twgh/xcgui/window"
+	"github.com/twgh/xcgui/xcc"
+)
+
+func main() {
+	// 初始化界面库
+	app.InitOrExit()
+	a := app.New(true)
+	a.EnableAutoDPI(true).EnableDPI(true)
+	// 设置默认字体
+	a.SetDefaultFont(font.NewEX("微软雅黑", 10, xcc.FontStyle_Regular).Handle)
+
+	// 创建窗口
+	w := window.New(0, 0, 760, 640, "xc-elementui 图标浏览器", 0, xcc.Window_Style_Default|xcc.Window_Style_Drag_Window)
+	// 设置窗口边框大小
+	w.SetBorderSize(0, 32, 0, 0)
+	// 设置窗口阴影, 圆角
+	w.SetTransparentType(xcc.Window_Transparent_Shadow).SetShadowInfo(8, 255, 10, false, 0).SetTransparentAlpha(255)
+	// 窗口启用布局, 自动换行, 行列间距10
+	w.EnableLayout(true).SetSpace(10).SetSpaceRow(10).EnableAutoWrap(true).SetPadding(10, 10, 10, 10)
+	// 窗口_置标题外间距, 设置标题内容(图标, 标题, 控制按钮)外间距.
+	w.SetCaptionMargin(3, 0, 0, 0)
+
+	// 创建 Elementui 对象
+	e := eui.NewElementui(12, w.GetDPI())
+
+	// 搜索框
+	search := e.CreateEdit(w.Handle, eui.EditOption{Icon: "fa-magnifying-glass", IsAutoColor: true, DefaultText: "搜索图标", Width: 280, Height: 32})
+
+	// 风格筛选
+	styles := []struct{ text, style string }{{"全部", ""}, {"solid", "fa-solid"}, {"regular", "fa-regular"}, {"brands", "fa-brands"}}
+	styleBtns := make([]*eui.Button, len(styles))
+	for i, st := range styles {
+		btn := e.CreateButton(st.text, w.Handle, eui.ButtonOption{IsPlain: i != 0, Style: eui.ButtonStyle_Primary, Width: 64, Height: 32})
+		styleBtns[i] = btn
+	}
+
+	// 分类筛选
+	categories := append([]string{""}, eui.Icons.Categories()...)
+	combo := widget.NewComboBox(0, 0, 180, 32, w.Handle)
+	combo.CreateAdapter()
+	combo.EnableEdit(false)
+	for _, c := range categories {
+		if c == "" {
+			c = "全部分类"
+		}
+		combo.AddItemText(c)
+	}
+	combo.SetSelItem(0)
+
+	// 图标选择器, 使用大一些的图标
+	picker := e.CreateIconPicker(w.Handle, eui.IconPickerOption{IconSize: 22, Width: 736, Height: 460})
+	picker.LayoutItem_EnableWrap(true)
+
+	// 选中图标的信息
+	info := e.CreateEdit(w.Handle, eui.EditOption{DefaultText: "点击图标查看信息", Width: 400, Height: 32})
+	info.LayoutItem_EnableWrap(true)
+	info.EnableReadOnly(true)
+	// 隐藏的编辑框, 复制时借用它来放入剪贴板, 信息编辑框的内容不会被改掉
+	clip := widget.NewEdit(0, 0, 1, 1, w.Handle)
+	clip.Show(false)
+	copyText := func(text string) {
+		clip.SetText(text)
+		clip.SelectAll()
+		clip.ClipboardCopy()
+	}
+	btnName := e.CreateButton("复制名字", w.Handle, eui.ButtonOption{Icon: "fa-regular fa-copy", Size: eui.ButtonSize_Small})
+	btnHex := e.CreateButton("十六进制", w.Handle, eui.ButtonOption{Icon: "fa-regular fa-copy", Size: eui.ButtonSize_Small})
+	btnDec := e.CreateButton("十进制", w.Handle, eui.ButtonOption{Icon: "fa-regular fa-copy", Size: eui.ButtonSize_Small})
+
+	// 显示图标数量
+	updateTitle := func() {
+		w.SetTitle("xc-elementui 图标浏览器 - " + strconv.Itoa(picker.GetCount()) + " 个图标")
+		w.Redraw(false)
+	}
+	updateTitle()
+
+	search.Event_EDIT_CHANGED1(func(hEle int, pbHandled *bool) int {
+		picker.SetQuery(search.GetText_Temp())
+		updateTitle()
+		return 0
+	})
+	for i := range styleBtns {
+		i := i
+		styleBtns[i].AddEvent_BnClick(func(hEle int, pbHandled *bool) int {
+			for j, btn := range styleBtns {
+				// 改变朴素按钮后需重新设置样式
+				btn.EnablePlain(j != i).SetStyle(eui.ButtonStyle_Primary)
+				btn.Redraw(false)
+			}
+			picker.SetStyleFilter(styles[i].style)
+			updateTitle()
+			return 0
+		})
+	}
+	combo.Event_ComboBox_Select_End(func(iItem int32, pbHandled *bool) int {
+		if iItem >= 0 && int(iItem) < len(categories) {
+			picker.SetCategoryFilter(categories[iItem])
+			updateTitle()
+		}
+		return 0
+	})
+
+	picker.SetOnSelect(func(icon eui.Icon, style string) {
+		info.SetText(icon.FullName(style) + "    " + icon.Hex() + "    " + strconv.Itoa(int(icon.Unicode)))
+		info.Redraw(false)
+	})
+	btnName.AddEvent_BnClick(func(hEle int, pbHandled *bool) int {
+		if icon, style, ok := picker.GetSelected(); ok {
+			copyText(icon.FullName(style))
+		}
+		return 0
+	})
+	btnHex.AddEvent_BnClick(func(hEle int, pbHandled *bool) int {
+		if icon, _, ok := picker.GetSelected(); ok {
+			copyText(icon.Hex())
+		}
+		return 0
+	})
+	btnDec.AddEvent_BnClick(func(hEle int, pbHandled *bool) int {
+		if icon, _, ok := picker.GetSelected(); ok {
+			copyText(strconv.Itoa(int(icon.Unicode)))
+		}
+		return 0
+	})
+
+	w.Show(true)
+	a.Run()
+	a.Exit()
+}
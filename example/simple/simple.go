@@ -1,3 +1,5 @@
+//go:build windows
+
 // 简单例子
 package main
 
@@ -78,6 +80,21 @@ func main() {
 
 		// 图标按钮 默认按钮 自定义svg图标
 		e.CreateButton("", w.Handle, eui.ButtonOption{HSvg: svgElement.Handle, Width: 40, Height: 40})
+
+		// 切换亮色/暗色主题, 所有元素会自动重绘
+		btnTheme := e.CreateButton("暗色主题", w.Handle, eui.ButtonOption{Icon: "fa-moon"})
+		btnTheme.AddEvent_BnClick(func(hEle int, pbHandled *bool) int {
+			if e.IsDark() {
+				e.SetThemeMode(eui.ThemeMode_Light)
+				btnTheme.SetText("暗色主题")
+				btnTheme.SetIconName("fa-moon")
+			} else {
+				e.SetThemeMode(eui.ThemeMode_Dark)
+				btnTheme.SetText("亮色主题")
+				btnTheme.SetIconName("fa-sun")
+			}
+			return 0
+		})
 	}
 
 	// 编辑框
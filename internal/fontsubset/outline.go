@@ -0,0 +1,336 @@
+package fontsubset
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Glyph 是一个字符的字形轮廓和度量, 单位是字体单位.
+type Glyph struct {
+	// svg 路径, 坐标已转换成 y 轴向下, 原点是字符左边与上升线的交点, 即 (0, Ascent) 变成 (0, 0). 空白字符为空.
+	Path string
+	// 前进宽度.
+	Advance int
+	// 每 em 的单位数, FontAwesome 是 512.
+	UnitsPerEm int
+	// 上升高度, 是正数.
+	Ascent int
+	// 下降高度, 一般是负数.
+	Descent int
+}
+
+// point 是字形轮廓上的点.
+type point struct {
+	x, y float64
+	on   bool // 在曲线上, 否则是二次贝塞尔曲线的控制点
+}
+
+// Outline 返回字符的字形轮廓.
+//
+// ttf: TrueType 字体数据.
+//
+// r: 字符.
+func Outline(ttf []byte, r rune) (Glyph, error) {
+	tables, err := parseTables(ttf)
+	if err != nil {
+		return Glyph{}, err
+	}
+	for _, tag := range []string{"cmap", "glyf", "head", "hhea", "hmtx", "loca", "maxp"} {
+		if tables[tag] == nil {
+			return Glyph{}, errors.New("fontsubset: missing table " + tag)
+		}
+	}
+	head, hhea, hmtx := tables["head"], tables["hhea"], tables["hmtx"]
+	if len(head) < 54 || len(hhea) < 36 || len(tables["maxp"]) < 6 {
+		return Glyph{}, errors.New("fontsubset: invalid head, hhea or maxp table")
+	}
+	numGlyphs := int(binary.BigEndian.Uint16(tables["maxp"][4:]))
+	offsets, err := parseLoca(tables["loca"], numGlyphs, int16(binary.BigEndian.Uint16(head[50:])))
+	if err != nil {
+		return Glyph{}, err
+	}
+	cmap, err := parseCmap(tables["cmap"])
+	if err != nil {
+		return Glyph{}, err
+	}
+	gid, ok := cmap[r]
+	if !ok {
+		return Glyph{}, fmt.Errorf("fontsubset: no glyph for %U", r)
+	}
+
+	g := Glyph{
+		UnitsPerEm: int(binary.BigEndian.Uint16(head[18:])),
+		Ascent:     int(int16(binary.BigEndian.Uint16(hhea[4:]))),
+		Descent:    int(int16(binary.BigEndian.Uint16(hhea[6:]))),
+	}
+	// 超过 numberOfHMetrics 的字形使用最后一个前进宽度
+	numMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	if i := int(gid); numMetrics > 0 {
+		if i >= numMetrics {
+			i = numMetrics - 1
+		}
+		if len(hmtx) >= i*4+2 {
+			g.Advance = int(binary.BigEndian.Uint16(hmtx[i*4:]))
+		}
+	}
+
+	contours, err := glyphContours(tables["glyf"], offsets, gid, 0)
+	if err != nil {
+		return Glyph{}, err
+	}
+	g.Path = svgPath(contours, float64(g.Ascent))
+	return g, nil
+}
+
+// glyphContours 返回字形的轮廓, 复合字形会合并组件的轮廓.
+//
+// depth: 复合字形的嵌套深度, 防止循环引用.
+func glyphContours(glyf []byte, offsets []uint32, gid uint16, depth int) ([][]point, error) {
+	if int(gid)+1 >= len(offsets) {
+		return nil, fmt.Errorf("fontsubset: glyph %d out of range", gid)
+	}
+	start, end := offsets[gid], offsets[gid+1]
+	if end > uint32(len(glyf)) || start > end {
+		return nil, fmt.Errorf("fontsubset: glyph %d out of range", gid)
+	}
+	g := glyf[start:end]
+	if len(g) == 0 { // 空白字符
+		return nil, nil
+	}
+	if len(g) < 10 {
+		return nil, fmt.Errorf("fontsubset: truncated glyph %d", gid)
+	}
+	n := int(int16(binary.BigEndian.Uint16(g)))
+	if n >= 0 {
+		return simpleContours(g, n, gid)
+	}
+	if depth > 8 {
+		return nil, errors.New("fontsubset: composite glyphs nested too deep")
+	}
+
+	// 复合字形, 每个组件按变换矩阵和偏移放置
+	var contours [][]point
+	p := 10
+	for {
+		if p+4 > len(g) {
+			return nil, fmt.Errorf("fontsubset: truncated composite glyph %d", gid)
+		}
+		flags := binary.BigEndian.Uint16(g[p:])
+		component := binary.BigEndian.Uint16(g[p+2:])
+		p += 4
+		var dx, dy float64
+		if flags&argWords != 0 {
+			if p+4 > len(g) {
+				return nil, fmt.Errorf("fontsubset: truncated composite glyph %d", gid)
+			}
+			dx, dy = float64(int16(binary.BigEndian.Uint16(g[p:]))), float64(int16(binary.BigEndian.Uint16(g[p+2:])))
+			p += 4
+		} else {
+			if p+2 > len(g) {
+				return nil, fmt.Errorf("fontsubset: truncated composite glyph %d", gid)
+			}
+			dx, dy = float64(int8(g[p])), float64(int8(g[p+1]))
+			p += 2
+		}
+		if flags&argsXY == 0 { // 按点对齐的组件很少见, 不支持, 当作没有偏移
+			dx, dy = 0, 0
+		}
+		a, b, c, d := 1.0, 0.0, 0.0, 1.0
+		f2dot14 := func(i int) float64 { return float64(int16(binary.BigEndian.Uint16(g[p+i:]))) / 16384 }
+		switch {
+		case flags&haveScale != 0 && p+2 <= len(g):
+			a = f2dot14(0)
+			d = a
+			p += 2
+		case flags&haveXYScale != 0 && p+4 <= len(g):
+			a, d = f2dot14(0), f2dot14(2)
+			p += 4
+		case flags&haveTwoByTwo != 0 && p+8 <= len(g):
+			a, b, c, d = f2dot14(0), f2dot14(2), f2dot14(4), f2dot14(6)
+			p += 8
+		}
+		sub, err := glyphContours(glyf, offsets, component, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		for _, contour := range sub {
+			for i, pt := range contour {
+				contour[i].x = a*pt.x + c*pt.y + dx
+				contour[i].y = b*pt.x + d*pt.y + dy
+			}
+			contours = append(contours, contour)
+		}
+		if flags&moreComponents == 0 {
+			return contours, nil
+		}
+	}
+}
+
+// simpleContours 解析简单字形的轮廓.
+//
+// n: 轮廓数量.
+func simpleContours(g []byte, n int, gid uint16) ([][]point, error) {
+	errTruncated := fmt.Errorf("fontsubset: truncated glyph %d", gid)
+	p := 10
+	if p+2*n+2 > len(g) {
+		return nil, errTruncated
+	}
+	endPts := make([]int, n)
+	for i := range endPts {
+		endPts[i] = int(binary.BigEndian.Uint16(g[p+2*i:]))
+	}
+	p += 2 * n
+	p += 2 + int(binary.BigEndian.Uint16(g[p:])) // 跳过指令
+	if n == 0 {
+		return nil, nil
+	}
+	numPts := endPts[n-1] + 1
+
+	// 标志位, 第 4 位表示重复
+	const (
+		onCurve    = 0x01
+		xShort     = 0x02
+		yShort     = 0x04
+		repeat     = 0x08
+		xSameOrPos = 0x10
+		ySameOrPos = 0x20
+	)
+	flags := make([]byte, 0, numPts)
+	for len(flags) < numPts {
+		if p >= len(g) {
+			return nil, errTruncated
+		}
+		f := g[p]
+		p++
+		flags = append(flags, f)
+		if f&repeat != 0 {
+			if p >= len(g) {
+				return nil, errTruncated
+			}
+			for k := 0; k < int(g[p]) && len(flags) < numPts; k++ {
+				flags = append(flags, f)
+			}
+			p++
+		}
+	}
+
+	// 坐标是相对前一个点的差值
+	coords := func(short, sameOrPos byte) ([]float64, error) {
+		vs := make([]float64, numPts)
+		v := 0
+		for i, f := range flags {
+			switch {
+			case f&short != 0:
+				if p >= len(g) {
+					return nil, errTruncated
+				}
+				if f&sameOrPos != 0 {
+					v += int(g[p])
+				} else {
+					v -= int(g[p])
+				}
+				p++
+			case f&sameOrPos == 0:
+				if p+2 > len(g) {
+					return nil, errTruncated
+				}
+				v += int(int16(binary.BigEndian.Uint16(g[p:])))
+				p += 2
+			}
+			vs[i] = float64(v)
+		}
+		return vs, nil
+	}
+	xs, err := coords(xShort, xSameOrPos)
+	if err != nil {
+		return nil, err
+	}
+	ys, err := coords(yShort, ySameOrPos)
+	if err != nil {
+		return nil, err
+	}
+
+	contours := make([][]point, 0, n)
+	first := 0
+	for _, last := range endPts {
+		if last < first || last >= numPts {
+			return nil, fmt.Errorf("fontsubset: invalid contour in glyph %d", gid)
+		}
+		contour := make([]point, 0, last-first+1)
+		for i := first; i <= last; i++ {
+			contour = append(contour, point{xs[i], ys[i], flags[i]&onCurve != 0})
+		}
+		contours = append(contours, contour)
+		first = last + 1
+	}
+	return contours, nil
+}
+
+// svgPath 把轮廓转换成 svg 路径, y 轴翻转成向下.
+//   - 两个相邻的控制点中间隐含一个曲线上的点.
+//
+// ascent: 上升高度, 翻转后的 y 坐标是 ascent - y.
+func svgPath(contours [][]point, ascent float64) string {
+	var b strings.Builder
+	cmd := func(c byte, pts ...point) {
+		b.WriteByte(c)
+		for i, pt := range pts {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(formatCoord(pt.x))
+			b.WriteByte(' ')
+			b.WriteString(formatCoord(ascent - pt.y))
+		}
+	}
+	mid := func(p, q point) point { return point{(p.x + q.x) / 2, (p.y + q.y) / 2, true} }
+	for _, contour := range contours {
+		if len(contour) == 0 {
+			continue
+		}
+		// 从曲线上的点开始, 都是控制点时从首尾两个控制点的中点开始
+		start, rest := contour[0], contour[1:]
+		if !start.on {
+			if last := contour[len(contour)-1]; last.on {
+				start, rest = last, contour[:len(contour)-1]
+			} else {
+				start, rest = mid(start, last), contour
+			}
+		}
+		cmd('M', start)
+		var ctrl *point
+		for i := range rest {
+			pt := rest[i]
+			switch {
+			case pt.on && ctrl == nil:
+				cmd('L', pt)
+			case pt.on:
+				cmd('Q', *ctrl, pt)
+				ctrl = nil
+			case ctrl != nil:
+				cmd('Q', *ctrl, mid(*ctrl, pt))
+				ctrl = &rest[i]
+			default:
+				ctrl = &rest[i]
+			}
+		}
+		if ctrl != nil {
+			cmd('Q', *ctrl, start)
+		}
+		b.WriteByte('Z')
+	}
+	return b.String()
+}
+
+// formatCoord 格式化坐标, 最多保留两位小数.
+func formatCoord(v float64) string {
+	v = math.Round(v*100) / 100
+	if v == 0 { // 避免输出 -0
+		v = 0
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,29 @@
+package fontsubset
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_Outline(t *testing.T) {
+	ttf, err := os.ReadFile("../../eui/res/fa-solid-900.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fa-paw
+	g, err := Outline(ttf, 0xf1b0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.UnitsPerEm != 512 || g.Ascent != 459 || g.Descent != -75 || g.Advance != 512 {
+		t.Errorf("metrics = %+v", g)
+	}
+	if !strings.HasPrefix(g.Path, "M") || !strings.HasSuffix(g.Path, "Z") || strings.Count(g.Path, "M") != 5 {
+		t.Errorf("paw should have 5 contours, path = %.80s...", g.Path)
+	}
+
+	if _, err := Outline(ttf, 0x10ffff); err == nil {
+		t.Error("want error for missing glyph")
+	}
+}
@@ -0,0 +1,433 @@
+// Package fontsubset 裁剪 TrueType 字体, 只保留指定字符的字形, 用于减小内置图标字体的大小. 也可以读取字形轮廓, 用于把图标转换成 svg.
+//   - 只支持 glyf 轮廓的 TrueType 字体, FontAwesome 的 ttf 就是这种.
+//   - 为了不改动复合字形和 hmtx, 字形编号保持不变, 没用到的字形只是变成空字形.
+package fontsubset
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Subset 返回只包含指定字符的字体.
+//   - 字体中没有的字符会被忽略.
+//   - 字形 0 (.notdef) 和复合字形引用的字形总会保留.
+//
+// ttf: TrueType 字体数据.
+//
+// runes: 要保留的字符.
+func Subset(ttf []byte, runes []rune) ([]byte, error) {
+	tables, err := parseTables(ttf)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range []string{"cmap", "glyf", "head", "hhea", "hmtx", "loca", "maxp"} {
+		if tables[tag] == nil {
+			return nil, errors.New("fontsubset: missing table " + tag)
+		}
+	}
+	head := tables["head"]
+	if len(head) < 54 || len(tables["maxp"]) < 6 {
+		return nil, errors.New("fontsubset: invalid head or maxp table")
+	}
+	numGlyphs := int(binary.BigEndian.Uint16(tables["maxp"][4:]))
+	offsets, err := parseLoca(tables["loca"], numGlyphs, int16(binary.BigEndian.Uint16(head[50:])))
+	if err != nil {
+		return nil, err
+	}
+	cmap, err := parseCmap(tables["cmap"])
+	if err != nil {
+		return nil, err
+	}
+
+	// 要保留的字符和字形
+	glyf := tables["glyf"]
+	keep := map[uint16]bool{0: true}
+	kept := make(map[rune]uint16)
+	for _, r := range runes {
+		if gid, ok := cmap[r]; ok && int(gid) < numGlyphs {
+			kept[r] = gid
+			if err := keepGlyph(glyf, offsets, gid, keep, 0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 重建 glyf 和 loca, 使用长格式 loca
+	var newGlyf []byte
+	newLoca := make([]byte, 0, (numGlyphs+1)*4)
+	for gid := 0; gid < numGlyphs; gid++ {
+		newLoca = appendUint32(newLoca, uint32(len(newGlyf)))
+		if keep[uint16(gid)] {
+			newGlyf = append(newGlyf, glyf[offsets[gid]:offsets[gid+1]]...)
+			for len(newGlyf)%4 != 0 {
+				newGlyf = append(newGlyf, 0)
+			}
+		}
+	}
+	newLoca = appendUint32(newLoca, uint32(len(newGlyf)))
+
+	newHead := append([]byte(nil), head...)
+	binary.BigEndian.PutUint16(newHead[50:], 1) // indexToLocFormat
+	binary.BigEndian.PutUint32(newHead[8:], 0)  // checkSumAdjustment, 写完后再计算
+
+	out := make(map[string][]byte, len(tables))
+	for tag, data := range tables {
+		switch tag {
+		case "cmap", "glyf", "head", "loca", "post":
+		case "hdmx", "LTSH", "VDMX", "DSIG", "kern", "GPOS", "GSUB":
+			// 与字形编号或排版相关, 图标字体用不到, 去掉
+		default:
+			out[tag] = data
+		}
+	}
+	out["cmap"] = buildCmap(kept)
+	out["glyf"] = newGlyf
+	out["head"] = newHead
+	out["loca"] = newLoca
+	if post := tables["post"]; len(post) >= 32 {
+		// 改成不带字形名的 3.0 版本
+		newPost := append([]byte(nil), post[:32]...)
+		binary.BigEndian.PutUint32(newPost, 0x00030000)
+		out["post"] = newPost
+	}
+	return writeFont(out), nil
+}
+
+// parseTables 解析字体的表目录, 返回表名对应的数据.
+func parseTables(ttf []byte) (map[string][]byte, error) {
+	if len(ttf) < 12 {
+		return nil, errors.New("fontsubset: font data too short")
+	}
+	if v := binary.BigEndian.Uint32(ttf); v != 0x00010000 && v != 0x74727565 { // 'true'
+		return nil, fmt.Errorf("fontsubset: unsupported font version %#x", v)
+	}
+	n := int(binary.BigEndian.Uint16(ttf[4:]))
+	if len(ttf) < 12+16*n {
+		return nil, errors.New("fontsubset: truncated table directory")
+	}
+	tables := make(map[string][]byte, n)
+	for i := 0; i < n; i++ {
+		rec := ttf[12+16*i:]
+		tag := string(rec[:4])
+		off := binary.BigEndian.Uint32(rec[8:])
+		length := binary.BigEndian.Uint32(rec[12:])
+		if uint64(off)+uint64(length) > uint64(len(ttf)) {
+			return nil, errors.New("fontsubset: table " + tag + " out of range")
+		}
+		tables[tag] = ttf[off : off+length]
+	}
+	return tables, nil
+}
+
+// parseLoca 解析 loca 表, 返回 numGlyphs+1 个字形偏移.
+//
+// format: head 表中的 indexToLocFormat, 0 是短格式, 1 是长格式.
+func parseLoca(loca []byte, numGlyphs int, format int16) ([]uint32, error) {
+	offsets := make([]uint32, numGlyphs+1)
+	if format == 0 {
+		if len(loca) < (numGlyphs+1)*2 {
+			return nil, errors.New("fontsubset: truncated loca table")
+		}
+		for i := range offsets {
+			offsets[i] = uint32(binary.BigEndian.Uint16(loca[i*2:])) * 2
+		}
+	} else {
+		if len(loca) < (numGlyphs+1)*4 {
+			return nil, errors.New("fontsubset: truncated loca table")
+		}
+		for i := range offsets {
+			offsets[i] = binary.BigEndian.Uint32(loca[i*4:])
+		}
+	}
+	return offsets, nil
+}
+
+// keepGlyph 记录要保留的字形, 复合字形会同时保留它引用的字形.
+//
+// depth: 复合字形的嵌套深度, 防止循环引用.
+func keepGlyph(glyf []byte, offsets []uint32, gid uint16, keep map[uint16]bool, depth int) error {
+	keep[gid] = true
+	if int(gid)+1 >= len(offsets) {
+		return fmt.Errorf("fontsubset: glyph %d out of range", gid)
+	}
+	start, end := offsets[gid], offsets[gid+1]
+	if end > uint32(len(glyf)) || start > end {
+		return fmt.Errorf("fontsubset: glyph %d out of range", gid)
+	}
+	g := glyf[start:end]
+	if len(g) < 10 || int16(binary.BigEndian.Uint16(g)) >= 0 {
+		return nil
+	}
+	if depth > 8 {
+		return errors.New("fontsubset: composite glyphs nested too deep")
+	}
+
+	// 复合字形的组件
+	p := 10
+	for {
+		if p+4 > len(g) {
+			return fmt.Errorf("fontsubset: truncated composite glyph %d", gid)
+		}
+		flags := binary.BigEndian.Uint16(g[p:])
+		component := binary.BigEndian.Uint16(g[p+2:])
+		if !keep[component] {
+			if err := keepGlyph(glyf, offsets, component, keep, depth+1); err != nil {
+				return err
+			}
+		}
+		p += 4
+		if flags&argWords != 0 {
+			p += 4
+		} else {
+			p += 2
+		}
+		switch {
+		case flags&haveScale != 0:
+			p += 2
+		case flags&haveXYScale != 0:
+			p += 4
+		case flags&haveTwoByTwo != 0:
+			p += 8
+		}
+		if flags&moreComponents == 0 {
+			return nil
+		}
+	}
+}
+
+// 复合字形组件的标志位.
+const (
+	argWords       = 0x0001 // 偏移是 int16, 否则是 int8
+	argsXY         = 0x0002 // 偏移是坐标, 否则是点的编号
+	haveScale      = 0x0008 // 有一个缩放值
+	moreComponents = 0x0020 // 后面还有组件
+	haveXYScale    = 0x0040 // 有 x, y 两个缩放值
+	haveTwoByTwo   = 0x0080 // 有 2x2 变换矩阵
+)
+
+// parseCmap 解析 cmap 表, 返回字符对应的字形编号. 优先使用 Unicode 完整字符集的 format 12, 其次是 format 4.
+func parseCmap(cmap []byte) (map[rune]uint16, error) {
+	if len(cmap) < 4 {
+		return nil, errors.New("fontsubset: invalid cmap table")
+	}
+	n := int(binary.BigEndian.Uint16(cmap[2:]))
+	var sub4, sub12 []byte
+	for i := 0; i < n && 4+8*i+8 <= len(cmap); i++ {
+		rec := cmap[4+8*i:]
+		platform, encoding := binary.BigEndian.Uint16(rec), binary.BigEndian.Uint16(rec[2:])
+		off := binary.BigEndian.Uint32(rec[4:])
+		if off+2 > uint32(len(cmap)) {
+			continue
+		}
+		sub := cmap[off:]
+		unicode := platform == 0 || platform == 3 && (encoding == 1 || encoding == 10)
+		switch binary.BigEndian.Uint16(sub) {
+		case 4:
+			if unicode && sub4 == nil {
+				sub4 = sub
+			}
+		case 12:
+			if unicode && sub12 == nil {
+				sub12 = sub
+			}
+		}
+	}
+
+	m := make(map[rune]uint16)
+	switch {
+	case sub12 != nil:
+		if len(sub12) < 16 {
+			return nil, errors.New("fontsubset: invalid cmap format 12")
+		}
+		groups := int(binary.BigEndian.Uint32(sub12[12:]))
+		if len(sub12) < 16+12*groups {
+			return nil, errors.New("fontsubset: truncated cmap format 12")
+		}
+		for i := 0; i < groups; i++ {
+			g := sub12[16+12*i:]
+			start, end, gid := binary.BigEndian.Uint32(g), binary.BigEndian.Uint32(g[4:]), binary.BigEndian.Uint32(g[8:])
+			for c := start; c <= end && c-start < 0x10000; c++ {
+				m[rune(c)] = uint16(gid + c - start)
+			}
+		}
+	case sub4 != nil:
+		if len(sub4) < 14 {
+			return nil, errors.New("fontsubset: invalid cmap format 4")
+		}
+		segX2 := int(binary.BigEndian.Uint16(sub4[6:]))
+		if len(sub4) < 16+segX2*4 {
+			return nil, errors.New("fontsubset: truncated cmap format 4")
+		}
+		ends := sub4[14:]
+		starts := sub4[16+segX2:]
+		deltas := sub4[16+segX2*2:]
+		rangeOffsets := sub4[16+segX2*3:]
+		for i := 0; i < segX2; i += 2 {
+			end, start := binary.BigEndian.Uint16(ends[i:]), binary.BigEndian.Uint16(starts[i:])
+			delta, ro := binary.BigEndian.Uint16(deltas[i:]), binary.BigEndian.Uint16(rangeOffsets[i:])
+			for c := uint32(start); c <= uint32(end) && c != 0xffff; c++ {
+				var gid uint16
+				if ro == 0 {
+					gid = uint16(c) + delta
+				} else {
+					// idRangeOffset 是相对于它自己位置的偏移
+					p := 16 + segX2*3 + i + int(ro) + int(c-uint32(start))*2
+					if p+2 > len(sub4) {
+						continue
+					}
+					if gid = binary.BigEndian.Uint16(sub4[p:]); gid != 0 {
+						gid += delta
+					}
+				}
+				if gid != 0 {
+					m[rune(c)] = gid
+				}
+			}
+		}
+	default:
+		return nil, errors.New("fontsubset: no unicode cmap subtable")
+	}
+	return m, nil
+}
+
+// buildCmap 生成 cmap 表, 包含 format 4 和 format 12 子表, 与 FontAwesome 原来的结构相同.
+func buildCmap(kept map[rune]uint16) []byte {
+	runes := make([]rune, 0, len(kept))
+	for r := range kept {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	// format 4, 每个字符一段, 最后是 0xFFFF 结束段
+	var bmp []rune
+	for _, r := range runes {
+		if r < 0xffff {
+			bmp = append(bmp, r)
+		}
+	}
+	segX2 := (len(bmp) + 1) * 2
+	searchRange, entrySelector := 2, 0
+	for searchRange*2 <= segX2 {
+		searchRange *= 2
+		entrySelector++
+	}
+	length := 16 + segX2*4
+	sub4 := make([]byte, 0, length)
+	sub4 = appendUint16(sub4, 4)
+	sub4 = appendUint16(sub4, uint16(length))
+	sub4 = appendUint16(sub4, 0) // language
+	sub4 = appendUint16(sub4, uint16(segX2))
+	sub4 = appendUint16(sub4, uint16(searchRange))
+	sub4 = appendUint16(sub4, uint16(entrySelector))
+	sub4 = appendUint16(sub4, uint16(segX2-searchRange))
+	for _, r := range bmp {
+		sub4 = appendUint16(sub4, uint16(r))
+	}
+	sub4 = appendUint16(sub4, 0xffff)
+	sub4 = appendUint16(sub4, 0) // reservedPad
+	for _, r := range bmp {
+		sub4 = appendUint16(sub4, uint16(r))
+	}
+	sub4 = appendUint16(sub4, 0xffff)
+	for _, r := range bmp {
+		sub4 = appendUint16(sub4, kept[r]-uint16(r))
+	}
+	sub4 = appendUint16(sub4, 1)
+	for i := 0; i <= len(bmp); i++ {
+		sub4 = appendUint16(sub4, 0)
+	}
+
+	// format 12, 每个字符一组
+	sub12 := make([]byte, 0, 16+12*len(runes))
+	sub12 = appendUint16(sub12, 12)
+	sub12 = appendUint16(sub12, 0)
+	sub12 = appendUint32(sub12, uint32(16+12*len(runes)))
+	sub12 = appendUint32(sub12, 0) // language
+	sub12 = appendUint32(sub12, uint32(len(runes)))
+	for _, r := range runes {
+		sub12 = appendUint32(sub12, uint32(r))
+		sub12 = appendUint32(sub12, uint32(r))
+		sub12 = appendUint32(sub12, uint32(kept[r]))
+	}
+
+	// 编码记录: (0,3) (3,1) 指向 format 4, (0,4) (3,10) 指向 format 12
+	off4, off12 := uint32(4+8*4), uint32(4+8*4+len(sub4))
+	cmap := make([]byte, 0, int(off12)+len(sub12))
+	cmap = appendUint16(cmap, 0)
+	cmap = appendUint16(cmap, 4)
+	for _, rec := range [][3]uint32{{0, 3, off4}, {0, 4, off12}, {3, 1, off4}, {3, 10, off12}} {
+		cmap = appendUint16(cmap, uint16(rec[0]))
+		cmap = appendUint16(cmap, uint16(rec[1]))
+		cmap = appendUint32(cmap, rec[2])
+	}
+	cmap = append(cmap, sub4...)
+	return append(cmap, sub12...)
+}
+
+// writeFont 把表写成字体文件, 表按名字排序并 4 字节对齐, 最后计算 head 表的 checkSumAdjustment.
+func writeFont(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	n := len(tags)
+	searchRange, entrySelector := 1, 0
+	for searchRange*2 <= n {
+		searchRange *= 2
+		entrySelector++
+	}
+	searchRange *= 16
+	buf := make([]byte, 0, 1<<16)
+	buf = appendUint32(buf, 0x00010000)
+	buf = appendUint16(buf, uint16(n))
+	buf = appendUint16(buf, uint16(searchRange))
+	buf = appendUint16(buf, uint16(entrySelector))
+	buf = appendUint16(buf, uint16(n*16-searchRange))
+
+	off := uint32(12 + 16*n)
+	var headOff uint32
+	for _, tag := range tags {
+		data := tables[tag]
+		if tag == "head" {
+			headOff = off
+		}
+		buf = append(buf, tag...)
+		buf = appendUint32(buf, checksum(data))
+		buf = appendUint32(buf, off)
+		buf = appendUint32(buf, uint32(len(data)))
+		off += uint32((len(data) + 3) &^ 3)
+	}
+	for _, tag := range tags {
+		buf = append(buf, tables[tag]...)
+		for len(buf)%4 != 0 {
+			buf = append(buf, 0)
+		}
+	}
+	binary.BigEndian.PutUint32(buf[headOff+8:], 0xB1B0AFBA-checksum(buf))
+	return buf
+}
+
+// checksum 计算表的校验和, 按大端 uint32 累加, 不足 4 字节的部分补 0.
+func checksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var b [4]byte
+		copy(b[:], data[i:])
+		sum += binary.BigEndian.Uint32(b[:])
+	}
+	return sum
+}
+
+// appendUint16 把 v 按大端追加到 b 后面.
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+// appendUint32 把 v 按大端追加到 b 后面.
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
@@ -0,0 +1,80 @@
+package fontsubset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+)
+
+func Test_Subset(t *testing.T) {
+	for _, name := range []string{"fa-solid-900.ttf", "fa-regular-400.ttf", "fa-brands-400.ttf"} {
+		ttf, err := os.ReadFile("../../eui/res/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		src, _ := parseTables(ttf)
+		srcCmap, _ := parseCmap(src["cmap"])
+		srcGlyphs := glyphs(t, src)
+
+		// 取字体中的前两个图标字符, 再加一个字体中没有的字符
+		var runes []rune
+		for r := rune(0xe000); r < 0xf8ff && len(runes) < 2; r++ {
+			if _, ok := srcCmap[r]; ok {
+				runes = append(runes, r)
+			}
+		}
+		runes = append(runes, 0x10ffff)
+
+		out, err := Subset(ttf, runes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(out)*10 > len(ttf) {
+			t.Errorf("%s: subset size %d, original %d", name, len(out), len(ttf))
+		}
+		if sum := checksum(out); sum != 0xB1B0AFBA {
+			t.Errorf("%s: file checksum = %#x", name, sum)
+		}
+
+		dst, err := parseTables(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmap, err := parseCmap(dst["cmap"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cmap) != 2 {
+			t.Errorf("%s: cmap has %d entries, want 2", name, len(cmap))
+		}
+		dstGlyphs := glyphs(t, dst)
+		for _, r := range runes[:2] {
+			gid := srcCmap[r]
+			if cmap[r] != gid {
+				t.Errorf("%s: %x maps to glyph %d, want %d", name, r, cmap[r], gid)
+			}
+			if len(dstGlyphs[gid]) == 0 || !bytes.Equal(dstGlyphs[gid], srcGlyphs[gid]) {
+				t.Errorf("%s: glyph %d of %x differs", name, gid, r)
+			}
+		}
+		if len(dstGlyphs) != len(srcGlyphs) {
+			t.Errorf("%s: %d glyphs, want %d", name, len(dstGlyphs), len(srcGlyphs))
+		}
+	}
+}
+
+// glyphs 返回字体中每个字形的数据, 去掉了对齐用的 0.
+func glyphs(t *testing.T, tables map[string][]byte) [][]byte {
+	t.Helper()
+	n := int(binary.BigEndian.Uint16(tables["maxp"][4:]))
+	offsets, err := parseLoca(tables["loca"], n, int16(binary.BigEndian.Uint16(tables["head"][50:])))
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := make([][]byte, n)
+	for i := range list {
+		list[i] = bytes.TrimRight(tables["glyf"][offsets[i]:offsets[i+1]], "\x00")
+	}
+	return list
+}